@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Environment variables set by CI systems that hold the number of the pull request being built.
+var pullRequestEnvVars = []string{
+	"CHANGE_ID",                            // Jenkins multibranch
+	"CI_MERGE_REQUEST_IID",                 // GitLab
+	"SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", // Azure Pipelines
+	"TRAVIS_PULL_REQUEST",                  // Travis CI, false when not a pull request
+	"CIRCLE_PR_NUMBER",                     // CircleCI, for pull requests from forks
+	"BITBUCKET_PR_ID",                      // Bitbucket Pipelines
+}
+
+// Environment variables set by CI systems that hold the git ref being built, e.g. refs/heads/main, refs/tags/v1.0.0 or refs/pull/42/merge.
+var refEnvVars = []string{
+	"GITHUB_REF",         // GitHub Actions
+	"BUILD_SOURCEBRANCH", // Azure Pipelines
+}
+
+// Environment variables set by CI systems that hold the name of the tag being built.
+var tagEnvVars = []string{
+	"CI_COMMIT_TAG", // GitLab
+	"TAG_NAME",      // Jenkins multibranch
+	"BUILDKITE_TAG", // Buildkite
+	"CIRCLE_TAG",    // CircleCI
+	"TRAVIS_TAG",    // Travis CI
+	"BITBUCKET_TAG", // Bitbucket Pipelines
+}
+
+// Environment variables set by CI systems that hold the name of the branch being built.
+var branchEnvVars = []string{
+	"CI_COMMIT_BRANCH", // GitLab
+	"BRANCH_NAME",      // Jenkins multibranch
+	"BUILDKITE_BRANCH", // Buildkite
+	"CIRCLE_BRANCH",    // CircleCI
+	"TRAVIS_BRANCH",    // Travis CI
+	"BITBUCKET_BRANCH", // Bitbucket Pipelines
+	"GIT_BRANCH",       // Jenkins git plugin, e.g. origin/main
+}
+
+// DefaultReleaseBranchPattern is the default regex for release branch names, where the version group is the release's major and minor version.
+const DefaultReleaseBranchPattern = `^(?:release|hotfix)[/-]v?(?P<version>\d+\.\d+)(?:\.x)?$`
+
+var pullRequestRef = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+var invalidIdentifierChars = regexp.MustCompile(`[^0-9A-Za-z-]+`)
+
+// DetectBranch returns the name of the branch being built.
+//
+// CI environment variables are checked first, as CI systems usually build a detached HEAD.  Pull requests are named pr-<number>.  If no CI environment
+// variable is set then the current branch of the git repo in dir is returned.
+//
+// An empty string is returned when a tag or a detached HEAD is being built, as there is no branch.
+func DetectBranch(dir string) (string, error) {
+	for _, e := range pullRequestEnvVars {
+		if n := os.Getenv(e); n != "" && n != "false" {
+			return "pr-" + n, nil
+		}
+	}
+	for _, e := range refEnvVars {
+		ref := os.Getenv(e)
+		if m := pullRequestRef.FindStringSubmatch(ref); m != nil {
+			return "pr-" + m[1], nil
+		}
+		if strings.HasPrefix(ref, "refs/tags/") {
+			return "", nil
+		}
+		if strings.HasPrefix(ref, "refs/heads/") {
+			return strings.TrimPrefix(ref, "refs/heads/"), nil
+		}
+	}
+	for _, e := range tagEnvVars {
+		if os.Getenv(e) != "" {
+			return "", nil
+		}
+	}
+	for _, e := range branchEnvVars {
+		if b := os.Getenv(e); b != "" {
+			return strings.TrimPrefix(b, "origin/"), nil
+		}
+	}
+
+	branch, err := runGit(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// PreReleaseIdentifier converts a branch name into a semver pre-release identifier.
+//
+// E.g. feature/Login becomes feature-login.
+func PreReleaseIdentifier(branch string) string {
+	id := invalidIdentifierChars.ReplaceAllString(strings.ToLower(branch), "-")
+	return strings.Trim(id, "-")
+}
+
+// currentBranch returns NewRelVer.Branch, or the detected branch if it is not set.
+func (r NewRelVer) currentBranch() (string, error) {
+	if r.Branch != "" {
+		return r.Branch, nil
+	}
+	branch, err := DetectBranch(r.Dir)
+	if err == nil && r.Debug {
+		fmt.Printf("detected branch %s\n", branch)
+	}
+	return branch, err
+}
+
+// branchPreRelease returns v with a pre-release identifier for the current branch, unless the current branch is the default branch.
+//
+// The pre-release identifier is the sanitized branch name followed by the build number on that branch, which is one more than the highest build number
+// found in the tags for the same version and branch.
+//
+// E.g. if v is 1.4.0, the branch is feature/login and there is a tag v1.4.0-feature-login.2, then 1.4.0-feature-login.3 is returned.
+func (r NewRelVer) branchPreRelease(v *semver.Version, tags []string) (*semver.Version, error) {
+	branch, err := r.currentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		// Tags and detached HEADs are built like the default branch
+		if r.Debug {
+			fmt.Println("no branch detected, skipping branch pre-release")
+		}
+		return v, nil
+	}
+	if branch == r.DefaultBranch {
+		return v, nil
+	}
+	id := PreReleaseIdentifier(branch)
+	if id == "" {
+		return nil, fmt.Errorf("branch %q has no valid pre-release identifier characters", branch)
+	}
+
+	build := 0
+	prefix := id + "."
+	for _, t := range tags {
+		tv, _ := NewSemVer(t)
+		if tv == nil || tv.Major != v.Major || tv.Minor != v.Minor || tv.Patch != v.Patch {
+			continue
+		}
+		pre := string(tv.PreRelease)
+		if !strings.HasPrefix(pre, prefix) {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(pre, prefix)); err == nil && n > build {
+			build = n
+		}
+	}
+
+	v.PreRelease = semver.PreRelease(fmt.Sprintf("%s%d", prefix, build+1))
+	v.Metadata = ""
+	return v, nil
+}
+
+// releaseBranch returns a copy of r for the release of the current branch if NewRelVer.ReleaseBranch is set and the branch matches
+// NewRelVer.ReleaseBranchPattern.
+//
+// The copy has NewRelVer.SameRelease set, NewRelVer.BranchPreRelease unset and, unless it is already set, NewRelVer.BaseVersion set to the branch's
+// version.  The version is taken from the pattern's version group, or the first group if it has none.
+//
+// E.g. on branch release/1.4 the release is the same as with -base-version 1.4 -same-release.
+func (r NewRelVer) releaseBranch() (NewRelVer, error) {
+	if !r.ReleaseBranch {
+		return r, nil
+	}
+	pattern := r.ReleaseBranchPattern
+	if pattern == "" {
+		pattern = DefaultReleaseBranchPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return r, fmt.Errorf("invalid release branch pattern: %v", err)
+	}
+	group := re.SubexpIndex("version")
+	if group < 0 {
+		group = 1
+	}
+	if re.NumSubexp() < group {
+		return r, fmt.Errorf("release branch pattern %q has no version group", pattern)
+	}
+
+	branch, err := r.currentBranch()
+	if err != nil {
+		return r, err
+	}
+	m := re.FindStringSubmatch(branch)
+	if m == nil {
+		if r.Debug {
+			fmt.Printf("branch %s is not a release branch\n", branch)
+		}
+		return r, nil
+	}
+	if r.BaseVersion == "" {
+		r.BaseVersion = m[group]
+	}
+	r.SameRelease = true
+	// Release branches produce releases, like the default branch
+	r.BranchPreRelease = false
+	if r.Debug {
+		fmt.Printf("using base version %s for release branch %s\n", r.BaseVersion, branch)
+	}
+	return r, nil
+}
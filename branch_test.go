@@ -0,0 +1,114 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func clearCIEnv(t *testing.T) {
+	for _, vars := range [][]string{pullRequestEnvVars, refEnvVars, tagEnvVars, branchEnvVars} {
+		for _, e := range vars {
+			t.Setenv(e, "")
+		}
+	}
+}
+
+func TestDetectBranchRef(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("BUILD_SOURCEBRANCH", "refs/heads/feature/login")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "feature/login", b)
+}
+
+func TestDetectBranchTagRef(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_REF", "refs/tags/v1.2.3")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", b)
+}
+
+func TestDetectBranchTagEnv(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("TRAVIS_TAG", "v1.2.3")
+	t.Setenv("TRAVIS_BRANCH", "v1.2.3")
+	t.Setenv("TRAVIS_PULL_REQUEST", "false")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", b)
+}
+
+func TestDetectBranchJenkinsGitBranch(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GIT_BRANCH", "origin/feature/login")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "feature/login", b)
+}
+
+func TestDetectBranchDetachedHead(t *testing.T) {
+	clearCIEnv(t)
+	dir := newTestRepo(t)
+	_, err := runGit(dir, "checkout", "-q", "--detach", "HEAD")
+	assert.NoError(t, err)
+
+	b, err := DetectBranch(dir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", b)
+}
+
+func TestGetNewVersionBranchPreReleaseTagBuild(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_REF", "refs/tags/v1.0.3")
+
+	r := NewRelVer{
+		Dir:              "examples",
+		BranchPreRelease: true,
+		DefaultBranch:    "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.0.2"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.3", v.String())
+}
+
+func TestDetectBranchPullRequest(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_REF", "refs/pull/42/merge")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "pr-42", b)
+}
+
+func TestDetectBranchEnv(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("BRANCH_NAME", "feature/login")
+
+	b, err := DetectBranch(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "feature/login", b)
+}
+
+func TestPreReleaseIdentifier(t *testing.T) {
+	assert.Equal(t, "feature-login", PreReleaseIdentifier("feature/Login"))
+	assert.Equal(t, "fix-jira-12-crash", PreReleaseIdentifier("fix/JIRA_12 crash!"))
+	assert.Equal(t, "pr-123", PreReleaseIdentifier("pr-123"))
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCalVerFormat is the CalVer format used when none is given.
+const DefaultCalVerFormat = "YYYY.MM.MICRO"
+
+// CalVer format tokens, see https://calver.org/.
+const (
+	calVerFullYear    = "YYYY"
+	calVerShortYear   = "YY"
+	calVerPaddedYear  = "0Y"
+	calVerShortMonth  = "MM"
+	calVerPaddedMonth = "0M"
+	calVerShortWeek   = "WW"
+	calVerPaddedWeek  = "0W"
+	calVerShortDay    = "DD"
+	calVerPaddedDay   = "0D"
+	calVerMicro       = "MICRO"
+)
+
+// Short years are the number of years since 2000, e.g. 26 for 2026.
+const calVerShortYearEpoch = 2000
+
+// CalVerFormat is a parsed CalVer format string, such as YYYY.MM.MICRO, with one token per dot separated version component.
+type CalVerFormat []string
+
+// ParseCalVerFormat parses a CalVer format string.
+//
+// Supported tokens are YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D and MICRO.  Weeks are ISO 8601 weeks and years are ISO 8601 week-numbering years when a week
+// token is used.
+func ParseCalVerFormat(format string) (CalVerFormat, error) {
+	tokens := strings.Split(format, ".")
+	for i, t := range tokens {
+		switch t {
+		case calVerFullYear, calVerShortYear, calVerPaddedYear,
+			calVerShortMonth, calVerPaddedMonth,
+			calVerShortWeek, calVerPaddedWeek,
+			calVerShortDay, calVerPaddedDay:
+		case calVerMicro:
+			if i != len(tokens)-1 {
+				return nil, fmt.Errorf("invalid CalVer format %q: MICRO must be the last component", format)
+			}
+		default:
+			return nil, fmt.Errorf("invalid CalVer format %q: unknown component %q", format, t)
+		}
+	}
+	return tokens, nil
+}
+
+// String returns the format string.
+func (f CalVerFormat) String() string {
+	return strings.Join(f, ".")
+}
+
+// micro returns the index of the MICRO component, or -1 if there is none.
+func (f CalVerFormat) micro() int {
+	if f[len(f)-1] == calVerMicro {
+		return len(f) - 1
+	}
+	return -1
+}
+
+// usesWeeks returns true if the format has a week component.
+func (f CalVerFormat) usesWeeks() bool {
+	for _, t := range f {
+		if t == calVerShortWeek || t == calVerPaddedWeek {
+			return true
+		}
+	}
+	return false
+}
+
+// CalVer is a calendar version.
+type CalVer struct {
+	Format CalVerFormat
+	Parts  []int
+}
+
+// NewCalVer returns the CalVer for date t with a MICRO component, if any, of 0.
+func NewCalVer(f CalVerFormat, t time.Time) *CalVer {
+	year, week := t.ISOWeek()
+	if !f.usesWeeks() {
+		year = t.Year()
+	}
+	parts := make([]int, len(f))
+	for i, token := range f {
+		switch token {
+		case calVerFullYear:
+			parts[i] = year
+		case calVerShortYear, calVerPaddedYear:
+			parts[i] = year - calVerShortYearEpoch
+		case calVerShortMonth, calVerPaddedMonth:
+			parts[i] = int(t.Month())
+		case calVerShortWeek, calVerPaddedWeek:
+			parts[i] = week
+		case calVerShortDay, calVerPaddedDay:
+			parts[i] = t.Day()
+		}
+	}
+	return &CalVer{Format: f, Parts: parts}
+}
+
+// ParseCalVer parses a version string, optionally prefixed with v, in the given format.
+func ParseCalVer(f CalVerFormat, v string) (*CalVer, error) {
+	fields := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(fields) != len(f) {
+		return nil, fmt.Errorf("version %q does not match CalVer format %s", v, f)
+	}
+	parts := make([]int, len(f))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || !calVerInRange(f[i], n) {
+			return nil, fmt.Errorf("version %q does not match CalVer format %s", v, f)
+		}
+		parts[i] = n
+	}
+	return &CalVer{Format: f, Parts: parts}, nil
+}
+
+// calVerInRange returns true if n is a valid value for the CalVer format token.
+func calVerInRange(token string, n int) bool {
+	switch token {
+	case calVerFullYear:
+		return n >= 1000
+	case calVerShortMonth, calVerPaddedMonth:
+		return n >= 1 && n <= 12
+	case calVerShortWeek, calVerPaddedWeek:
+		return n >= 1 && n <= 53
+	case calVerShortDay, calVerPaddedDay:
+		return n >= 1 && n <= 31
+	}
+	return n >= 0
+}
+
+// String returns the version formatted according to its CalVer format.
+func (v *CalVer) String() string {
+	fields := make([]string, len(v.Parts))
+	for i, n := range v.Parts {
+		switch v.Format[i] {
+		case calVerPaddedYear, calVerPaddedMonth, calVerPaddedWeek, calVerPaddedDay:
+			fields[i] = fmt.Sprintf("%02d", n)
+		default:
+			fields[i] = strconv.Itoa(n)
+		}
+	}
+	return strings.Join(fields, ".")
+}
+
+// Compare compares this version to another version.  This returns -1, 0, or 1 if this version is smaller, equal, or larger than the other version.
+func (v *CalVer) Compare(o *CalVer) int {
+	for i := range v.Parts {
+		if i >= len(o.Parts) {
+			return 1
+		}
+		if v.Parts[i] != o.Parts[i] {
+			if v.Parts[i] < o.Parts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if len(v.Parts) < len(o.Parts) {
+		return -1
+	}
+	return 0
+}
+
+// Next returns the version following v for date t.
+//
+// If t is in the same period as v, then the MICRO component is incremented.  Otherwise the version for t is returned with a MICRO component of 0.
+func (v *CalVer) Next(t time.Time) (*CalVer, error) {
+	next := NewCalVer(v.Format, t)
+	micro := v.Format.micro()
+	date := len(v.Parts)
+	if micro >= 0 {
+		date = micro
+	}
+
+	cmp := (&CalVer{Parts: next.Parts[:date]}).Compare(&CalVer{Parts: v.Parts[:date]})
+	switch {
+	case cmp > 0:
+		return next, nil
+	case cmp < 0:
+		return nil, fmt.Errorf("latest version %s is newer than today's version %s", v, next)
+	case micro < 0:
+		return nil, fmt.Errorf("version %s has already been released and CalVer format %s has no MICRO component", v, v.Format)
+	}
+	next.Parts[micro] = v.Parts[micro] + 1
+	return next, nil
+}
+
+// CalVerScheme is a VersionScheme for calendar versions, see https://calver.org/.
+//
+// New versions are derived from the current date, so base versions from version files are ignored and git tags that do not match the format are skipped.
+//
+// E.g. with a YYYY.MM.MICRO format in October 2026
+//
+// - If the latest version is 2026.10.2 then 2026.10.3 will be returned.
+//
+// - If the latest version is 2026.9.5, or there are no previous versions, then 2026.10.0 will be returned.
+type CalVerScheme struct {
+	Layout CalVerFormat
+	Now    func() time.Time
+}
+
+// NewCalVerScheme returns a CalVerScheme for the format string, or DefaultCalVerFormat if it is empty.
+//
+// The current date is taken from now, or time.Now if it is nil.
+func NewCalVerScheme(format string, now func() time.Time) (*CalVerScheme, error) {
+	if format == "" {
+		format = DefaultCalVerFormat
+	}
+	f, err := ParseCalVerFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &CalVerScheme{Layout: f, Now: now}, nil
+}
+
+// Parse converts a version string into a *CalVer.
+func (s *CalVerScheme) Parse(v string) (Version, error) {
+	cv, err := ParseCalVer(s.Layout, v)
+	if err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func (s *CalVerScheme) Compare(a, b Version) int {
+	return a.(*CalVer).Compare(b.(*CalVer))
+}
+
+// Bump returns the version following v for the current date.  The part is ignored.
+func (s *CalVerScheme) Bump(v Version, _ BumpPart) (Version, error) {
+	next, err := v.(*CalVer).Next(s.Now())
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// Format returns the string form of v.
+func (s *CalVerScheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns a version with all components set to 0, which is older than any date.
+func (s *CalVerScheme) Zero() Version {
+	return &CalVer{Format: s.Layout, Parts: make([]int, len(s.Layout))}
+}
+
+// UsesVersionFiles returns false, as calendar versions are derived from the date.
+func (s *CalVerScheme) UsesVersionFiles() bool {
+	return false
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func clock(year int, month time.Month, day int) func() time.Time {
+	return func() time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	}
+}
+
+func TestParseCalVerFormatInvalid(t *testing.T) {
+	_, err := ParseCalVerFormat("YYYY.MICRO.MM")
+	assert.Error(t, err)
+
+	_, err = ParseCalVerFormat("YYYY.QQ")
+	assert.Error(t, err)
+}
+
+func TestParseCalVer(t *testing.T) {
+	f, err := ParseCalVerFormat("YY.0M.MICRO")
+	assert.NoError(t, err)
+
+	v, err := ParseCalVer(f, "v26.03.4")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{26, 3, 4}, v.Parts)
+	assert.Equal(t, "26.03.4", v.String())
+
+	_, err = ParseCalVer(f, "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGetNewCalVersion(t *testing.T) {
+	scheme, err := NewCalVerScheme("YYYY.MM.MICRO", clock(2026, time.October, 18))
+	assert.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: scheme,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "2026.9.7", "2026.10.0", "v2026.10.2", "2026.10.1"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2026.10.3", v.String())
+}
+
+func TestGetNewCalVersionResetMicro(t *testing.T) {
+	scheme, err := NewCalVerScheme("YY.0M.MICRO", clock(2026, time.November, 2))
+	assert.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: scheme,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "26.10.5"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "26.11.0", v.String())
+}
+
+func TestGetNewCalVersionNoTags(t *testing.T) {
+	scheme, err := NewCalVerScheme("", clock(2026, time.October, 18))
+	assert.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: scheme,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2026.10.0", v.String())
+}
+
+func TestGetNewCalVersionWeeks(t *testing.T) {
+	scheme, err := NewCalVerScheme("YYYY.WW", clock(2027, time.January, 1))
+	assert.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: scheme,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"2026.52"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2026.53", v.String())
+
+	mockClient = &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"2026.53"}, nil)
+
+	_, err = r.GetNewVersion(mockClient)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+type cargoManifest struct {
+	Package struct {
+		// Version is either a version string or a table like {workspace = true}.
+		Version interface{} `toml:"version"`
+	} `toml:"package"`
+	Workspace *struct {
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+	} `toml:"workspace"`
+}
+
+func (r NewRelVer) unmarshalCargoVersion(data []byte) (string, error) {
+	var manifest cargoManifest
+	if _, err := toml.Decode(string(data), &manifest); err != nil {
+		return "0.0.0", fmt.Errorf("invalid Cargo.toml: %v", err)
+	}
+
+	switch v := manifest.Package.Version.(type) {
+	case string:
+		return v, nil
+	case map[string]interface{}:
+		if v["workspace"] != true {
+			break
+		}
+		// The package may be the workspace root itself
+		if manifest.Workspace != nil {
+			if manifest.Workspace.Package.Version != "" {
+				return manifest.Workspace.Package.Version, nil
+			}
+			return "0.0.0", errors.New("No workspace version found")
+		}
+		return r.findCargoWorkspaceVersion()
+	case nil:
+		if manifest.Workspace != nil && manifest.Workspace.Package.Version != "" {
+			return manifest.Workspace.Package.Version, nil
+		}
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+// findCargoWorkspaceVersion returns the [workspace.package] version of the Cargo.toml workspace root in a parent directory of NewRelVer.Dir.
+func (r NewRelVer) findCargoWorkspaceVersion() (string, error) {
+	dir, err := filepath.Abs(r.Dir)
+	if err != nil {
+		return "0.0.0", err
+	}
+	for parent := filepath.Dir(dir); parent != dir; dir, parent = parent, filepath.Dir(parent) {
+		data, err := ioutil.ReadFile(filepath.Join(parent, "Cargo.toml"))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "0.0.0", err
+		}
+		var manifest cargoManifest
+		if _, err := toml.Decode(string(data), &manifest); err != nil {
+			return "0.0.0", fmt.Errorf("invalid Cargo.toml in %s: %v", parent, err)
+		}
+		if manifest.Workspace == nil {
+			continue
+		}
+		if r.Debug {
+			fmt.Printf("found Cargo workspace in %s\n", parent)
+		}
+		if manifest.Workspace.Package.Version != "" {
+			return manifest.Workspace.Package.Version, nil
+		}
+		break
+	}
+	return "0.0.0", errors.New("No workspace version found")
+}
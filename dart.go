@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// PubspecYaml is the Dart and Flutter version file, whose versions may have a build number, e.g. 1.2.3+45.
+const PubspecYaml = "pubspec.yaml"
+
+// pubspecBuildNumber returns next with the next build number if the base version is read from a pubspec.yaml, where the build number must increase with every
+// release.  Otherwise next is returned as is.
+//
+// The build number is that of the base version plus the number of version tags higher than the base version, plus one, so it increases with every release
+// whether or not the tags have build numbers.  E.g. with version: 1.2.3+45, tags v1.2.3 and v1.2.4 give 1.2.5+47.  If the latest version has a higher build
+// number, e.g. v1.2.4+50, then one more than that is used instead.
+func (r NewRelVer) pubspecBuildNumber(next, latest, base Version, tags []string) Version {
+	if r.BaseVersion != "" || r.GoVersionFile != "" || r.Dockerfile != "" {
+		return next
+	}
+	file, err := r.FindVersionFile(PubspecYaml)
+	if err != nil {
+		return next
+	}
+	if _, err := unmarshalYAMLVersion(r, file); err != nil {
+		return next
+	}
+	sv, ok := next.(*semver.Version)
+	if !ok {
+		return next
+	}
+
+	build, found := uint64(0), false
+	if n, ok := buildNumber(base); ok {
+		build, found = n+uint64(r.countNewerTags(tags, base)), true
+	}
+	if n, ok := buildNumber(latest); ok && n >= build {
+		build, found = n, true
+	}
+	if !found {
+		return next
+	}
+	withBuild := *sv
+	withBuild.Metadata = strconv.FormatUint(build+1, 10)
+	return &withBuild
+}
+
+// buildNumber returns the numeric build metadata of a semver version, e.g. 45 for 1.2.3+45.
+func buildNumber(v Version) (uint64, bool) {
+	sv, ok := v.(*semver.Version)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(sv.Metadata, 10, 64)
+	return n, err == nil
+}
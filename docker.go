@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// DefaultDockerVersionArg is the Dockerfile build argument holding the version when NewRelVer.DockerVersionArg is not set.
+const DefaultDockerVersionArg = "VERSION"
+
+// dockerVersionLabels are the image labels holding the version, in order of preference.
+var dockerVersionLabels = []string{"org.opencontainers.image.version", "version"}
+
+var (
+	dockerContinuationRegex = regexp.MustCompile(`\\[ \t]*\r?\n`)
+	dockerInstructionRegex  = regexp.MustCompile(`(?i)^\s*(LABEL|ARG|ENV)\s+(.*)$`)
+	dockerKeyValueRegex     = regexp.MustCompile(`([\w.-]+)=("(?:[^"\\]|\\.)*"|'[^']*'|\S*)`)
+	dockerVariableRegex     = regexp.MustCompile(`\$(?:\{(\w+)\}|(\w+))`)
+)
+
+// findDockerfileVersion returns the version of a container image from the org.opencontainers.image.version or version LABEL of a Dockerfile, or else
+// from the default value of its NewRelVer.DockerVersionArg ARG, e.g. ARG VERSION=1.2.3.
+//
+// Labels may reference ARG and ENV variables, e.g. LABEL version=$VERSION.
+func (r NewRelVer) findDockerfileVersion(data []byte) (string, error) {
+	arg := r.DockerVersionArg
+	if arg == "" {
+		arg = DefaultDockerVersionArg
+	}
+
+	variables := map[string]string{}
+	labels := map[string]string{}
+	resolve := func(v string) string {
+		return dockerVariableRegex.ReplaceAllStringFunc(v, func(ref string) string {
+			m := dockerVariableRegex.FindStringSubmatch(ref)
+			return variables[m[1]+m[2]]
+		})
+	}
+	for _, line := range strings.Split(dockerContinuationRegex.ReplaceAllString(string(data), " "), "\n") {
+		m := dockerInstructionRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, kv := range dockerKeyValueRegex.FindAllStringSubmatch(m[2], -1) {
+			value := unquoteDockerValue(kv[2])
+			switch strings.ToUpper(m[1]) {
+			case "LABEL":
+				labels[kv[1]] = resolve(value)
+			default:
+				variables[kv[1]] = resolve(value)
+			}
+		}
+	}
+
+	for _, l := range dockerVersionLabels {
+		if v := strings.TrimSpace(labels[l]); v != "" {
+			return strings.TrimPrefix(v, "v"), nil
+		}
+	}
+	if v := strings.TrimSpace(variables[arg]); v != "" {
+		return strings.TrimPrefix(v, "v"), nil
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+func unquoteDockerValue(v string) string {
+	switch {
+	case len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"':
+		return strings.ReplaceAll(v[1:len(v)-1], `\"`, `"`)
+	case len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'':
+		return v[1 : len(v)-1]
+	}
+	return v
+}
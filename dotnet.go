@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// msbuildProject is an MSBuild project file, such as a *.csproj or Directory.Build.props file.
+type msbuildProject struct {
+	PropertyGroups []struct {
+		Version        string `xml:"Version"`
+		VersionPrefix  string `xml:"VersionPrefix"`
+		VersionSuffix  string `xml:"VersionSuffix"`
+		PackageVersion string `xml:"PackageVersion"`
+	} `xml:"PropertyGroup"`
+}
+
+// unmarshalMSBuildVersion returns the PackageVersion, Version or VersionPrefix and VersionSuffix properties of an MSBuild project, in that order.
+//
+// Properties that reference other properties, like $(VersionPrefix), are ignored.
+func unmarshalMSBuildVersion(_ NewRelVer, data []byte) (string, error) {
+	var project msbuildProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return "0.0.0", fmt.Errorf("invalid MSBuild project: %v", err)
+	}
+
+	var version, prefix, suffix, packageVersion string
+	for _, g := range project.PropertyGroups {
+		set := func(p *string, v string) {
+			if v = strings.TrimSpace(v); v != "" && !strings.Contains(v, "$(") {
+				*p = v
+			}
+		}
+		set(&version, g.Version)
+		set(&prefix, g.VersionPrefix)
+		set(&suffix, g.VersionSuffix)
+		set(&packageVersion, g.PackageVersion)
+	}
+
+	switch {
+	case packageVersion != "":
+		return packageVersion, nil
+	case version != "":
+		return version, nil
+	case prefix != "" && suffix != "":
+		return prefix + "-" + suffix, nil
+	case prefix != "":
+		return prefix, nil
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+func unmarshalNuspecVersion(_ NewRelVer, data []byte) (string, error) {
+	var nuspec struct {
+		Version string `xml:"metadata>version"`
+	}
+	xml.Unmarshal(data, &nuspec)
+	if nuspec.Version != "" {
+		return strings.TrimSpace(nuspec.Version), nil
+	}
+	return "0.0.0", errors.New("No version found")
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Version identifier regex strings for Elixir mix.exs files.
+//
+// The %s is replaced with VersionNumberRegex.
+const (
+	MixExsRegexf          = `(?m)^\s*version:\s*"(%s)"`
+	MixExsAttributeRegexf = `(?m)^\s*@%s\s+"(%s)"`
+)
+
+var mixExsAttributeRefRegex = regexp.MustCompile(`(?m)^\s*version:\s*@(\w+)`)
+
+// findMixVersion returns the version in the project/0 keyword list of a mix.exs file, which is either a string or a module attribute, such as @version.
+func findMixVersion(_ NewRelVer, data []byte) (string, error) {
+	if m := mixExsAttributeRefRegex.FindSubmatch(data); m != nil {
+		return matchVersion(data, fmt.Sprintf(MixExsAttributeRegexf, m[1], VersionNumberRegex), 1)
+	}
+	return matchVersion(data, fmt.Sprintf(MixExsRegexf, VersionNumberRegex), 1)
+}
@@ -0,0 +1,16 @@
+plugins {
+    id("com.android.application")
+}
+
+android {
+    namespace = "com.trendmicro.test"
+    compileSdk = 34
+
+    defaultConfig {
+        applicationId = "com.trendmicro.test"
+        minSdk = 24
+        targetSdk = 34
+        versionCode = 7
+        versionName = "2.3.4"
+    }
+}
@@ -0,0 +1,5 @@
+(defproject com.trendmicro/test "2.3.4"
+  :description "A test project.clj"
+  :dependencies [[org.clojure/clojure "1.11.1"]]
+  :main ^:skip-aot test.core
+  :profiles {:uberjar {:aot :all}})
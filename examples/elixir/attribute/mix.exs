@@ -0,0 +1,22 @@
+defmodule Test.MixProject do
+  use Mix.Project
+
+  @source_url "https://github.com/trendmicro/test"
+  @version "2.3.4"
+
+  def project do
+    [
+      app: :test,
+      version: @version,
+      elixir: "~> 1.15",
+      source_url: @source_url,
+      deps: deps()
+    ]
+  end
+
+  defp deps do
+    [
+      {:jason, "~> 1.4"}
+    ]
+  end
+end
@@ -0,0 +1,18 @@
+defmodule Test.MixProject do
+  use Mix.Project
+
+  def project do
+    [
+      app: :test,
+      version: "1.2.3",
+      elixir: "~> 1.15",
+      deps: deps()
+    ]
+  end
+
+  defp deps do
+    [
+      {:jason, "~> 1.4"}
+    ]
+  end
+end
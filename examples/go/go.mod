@@ -0,0 +1,3 @@
+module github.com/trendmicro/new-release-version/examples/go/v2
+
+go 1.18
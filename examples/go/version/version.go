@@ -0,0 +1,9 @@
+package version
+
+// Red Herring
+const MinVersion = "1.0.0"
+
+// Version is the version of this module.
+const Version = "2.3.4"
+
+var Commit = "unknown"
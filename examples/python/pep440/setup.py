@@ -0,0 +1,7 @@
+from setuptools import setup
+
+setup(
+    name='pep440-test',
+    version='2.1.0rc1',
+    description='A test setup.py script with a PEP 440 pre-release version',
+)
@@ -0,0 +1,6 @@
+"""A test package."""
+
+# Red Herring
+version = "0.0.1"
+
+__version__ = "4.5.6"
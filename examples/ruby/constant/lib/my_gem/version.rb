@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+module MyGem
+  VERSION = "2.3.4"
+end
@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+module FooBar
+  VERSION = '3.4.5'.freeze
+end
@@ -112,3 +112,18 @@ func (g *LocalGitClient) ListTags() ([]string, error) {
 	tags := strings.Split(str, "\n")
 	return tags, nil
 }
+
+// runGit runs a git command in dir and returns its output with surrounding whitespace removed.
+func runGit(dir string, args ...string) (string, error) {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("error finding git: %v", err)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running `git %s`: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
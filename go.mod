@@ -3,11 +3,15 @@ module github.com/trendmicro/new-release-version
 go 1.18
 
 require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/Masterminds/semver/v3 v3.2.1
 	github.com/coreos/go-semver v0.3.1
 	github.com/google/go-github/v32 v32.1.0
 	github.com/hashicorp/go-version v1.6.0
 	github.com/stretchr/testify v1.8.2
 	golang.org/x/oauth2 v0.5.0
+	gopkg.in/yaml.v3 v3.0.1
+	howett.net/plist v1.0.1
 )
 
 require (
@@ -20,5 +24,4 @@ require (
 	golang.org/x/net v0.23.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.33.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
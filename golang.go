@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultGoVersionNames are the names of the Go constants or variables that hold the version when NewRelVer.GoVersionName is not set.
+var DefaultGoVersionNames = []string{"Version", "version"}
+
+var goModuleRegex = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+
+var goMajorSuffixRegex = regexp.MustCompile(`/v(\d+)$`)
+
+// findGoVersion returns the version assigned to a string constant or variable in the Go source file NewRelVer.GoVersionFile, e.g.
+// const Version = "1.3.0".
+func (r NewRelVer) findGoVersion() (string, error) {
+	data, err := r.FindVersionFile(r.GoVersionFile)
+	if err != nil {
+		return "0.0.0", err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), r.GoVersionFile, data, 0)
+	if err != nil {
+		return "0.0.0", err
+	}
+
+	names := DefaultGoVersionNames
+	if r.GoVersionName != "" {
+		names = []string{r.GoVersionName}
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if !contains(names, name.Name) || i >= len(value.Values) {
+					continue
+				}
+				if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					v, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return "0.0.0", err
+					}
+					return strings.TrimPrefix(v, "v"), nil
+				}
+			}
+		}
+	}
+	return "0.0.0", fmt.Errorf("No %s string found in %s", strings.Join(names, " or "), r.GoVersionFile)
+}
+
+// checkGoModuleMajor returns an error if the major version of v does not match the /vN suffix of the module path in go.mod, see
+// https://go.dev/ref/mod#major-version-suffixes.
+//
+// Versions 0.x.x and 1.x.x need a module path without a suffix.  The check is only made when NewRelVer.GoVersionFile is set.
+func (r NewRelVer) checkGoModuleMajor(v Version) error {
+	if r.GoVersionFile == "" {
+		return nil
+	}
+	data, err := r.FindVersionFile("go.mod")
+	if err != nil {
+		return err
+	}
+	m := goModuleRegex.FindSubmatch(data)
+	if m == nil {
+		return errors.New("no module path found in go.mod")
+	}
+	module := string(m[1])
+
+	sv, err := NewSemVer(v.String())
+	if err != nil {
+		return err
+	}
+	major := int64(1)
+	if s := goMajorSuffixRegex.FindStringSubmatch(module); s != nil {
+		major, _ = strconv.ParseInt(s[1], 10, 64)
+	}
+	if sv.Major == major || (sv.Major == 0 && major == 1) {
+		return nil
+	}
+	if sv.Major <= 1 {
+		return fmt.Errorf("new version %s needs a module path without a major version suffix, but go.mod has module %s", v, module)
+	}
+	return fmt.Errorf("new version %s needs a /v%d module path suffix, but go.mod has module %s", v, sv.Major, module)
+}
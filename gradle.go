@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GradleVersionCatalog is the path of the default Gradle version catalog, see https://docs.gradle.org/current/userguide/platforms.html.
+const GradleVersionCatalog = "gradle/libs.versions.toml"
+
+// gradleCatalogRefRegex matches a version set from the default version catalog, e.g. version = libs.versions.app.get().
+var gradleCatalogRefRegex = regexp.MustCompile(`(?m)^version\s*=\s*libs\.versions\.([\w.]+?)(?:\.get\(\))?\s*$`)
+
+// findGradleVersion returns the version of a build.gradle or build.gradle.kts file, which is either a string, the versionName of an Android app or a
+// reference to a version in the GradleVersionCatalog.
+func (r NewRelVer) findGradleVersion(data []byte) (string, error) {
+	if v, err := matchVersion(data, fmt.Sprintf(BuildGradleRegexf, VersionNumberRegex), 1); err == nil {
+		return v, nil
+	}
+	if v, err := matchVersion(androidDefaultConfig(data), fmt.Sprintf(AndroidVersionNameRegexf, VersionNumberRegex), 1); err == nil {
+		return v, nil
+	}
+	m := gradleCatalogRefRegex.FindSubmatch(data)
+	if m == nil {
+		return "0.0.0", errors.New("No version found")
+	}
+
+	file, err := r.FindVersionFile(GradleVersionCatalog)
+	if err != nil {
+		return "0.0.0", err
+	}
+	var catalog struct {
+		Versions map[string]interface{} `toml:"versions"`
+	}
+	if _, err := toml.Decode(string(file), &catalog); err != nil {
+		return "0.0.0", fmt.Errorf("invalid %s: %v", GradleVersionCatalog, err)
+	}
+	// Gradle accessors separate aliases with dots, so my-app, my_app and my.app are all libs.versions.my.app
+	ref := string(m[1])
+	for alias, v := range catalog.Versions {
+		if strings.NewReplacer("-", ".", "_", ".").Replace(alias) != ref {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case map[string]interface{}:
+			// Rich versions, e.g. { strictly = "1.2.3" }
+			for _, k := range []string{"strictly", "require", "prefer"} {
+				if s, ok := v[k].(string); ok {
+					return s, nil
+				}
+			}
+		}
+	}
+	return "0.0.0", fmt.Errorf("No version %s found in %s", ref, GradleVersionCatalog)
+}
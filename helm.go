@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Chart.yaml keys that NewRelVer.ChartVersionKey can select.
+const (
+	ChartVersion    = "version"
+	ChartAppVersion = "appVersion"
+)
+
+func (r NewRelVer) unmarshalChartVersion(data []byte) (string, error) {
+	var chart struct {
+		Version    string `yaml:"version"`
+		AppVersion string `yaml:"appVersion"`
+	}
+	if err := yaml.Unmarshal(data, &chart); err != nil {
+		return "0.0.0", fmt.Errorf("invalid Chart.yaml: %v", err)
+	}
+
+	var version string
+	switch r.ChartVersionKey {
+	case "", ChartVersion:
+		version = chart.Version
+	case ChartAppVersion:
+		version = chart.AppVersion
+	default:
+		return "0.0.0", fmt.Errorf("unknown Chart.yaml version key %q", r.ChartVersionKey)
+	}
+	if version != "" {
+		return version, nil
+	}
+	return "0.0.0", errors.New("No version found")
+}
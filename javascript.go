@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// LernaIndependent is the lerna.json version of monorepos whose packages are versioned independently, see
+// https://lerna.js.org/docs/features/version-and-publish#versioning-strategies.
+const LernaIndependent = "independent"
+
+var jsonTrailingCommaRegex = regexp.MustCompile(`,(\s*[}\]])`)
+
+func unmarshalLernaVersion(r NewRelVer, data []byte) (string, error) {
+	v, err := unmarshalJSONVersion(r, data)
+	if err == nil && v == LernaIndependent {
+		return "0.0.0", errors.New("lerna.json packages are versioned independently")
+	}
+	return v, err
+}
+
+// unmarshalJSONCVersion returns the version of a JSON with comments file, such as deno.jsonc.
+func unmarshalJSONCVersion(r NewRelVer, data []byte) (string, error) {
+	return unmarshalJSONVersion(r, stripJSONComments(data))
+}
+
+// stripJSONComments removes // and /* */ comments, and trailing commas, from JSON with comments.
+func stripJSONComments(data []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, c)
+				i++
+				c = data[i]
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+			continue
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+			out = append(out, ' ')
+			continue
+		}
+		out = append(out, c)
+	}
+	return jsonTrailingCommaRegex.ReplaceAll(out, []byte("$1"))
+}
+
+// lernaTagPrefix returns a copy of the config with a TagPrefix of the package name followed by @, e.g. my-pkg@, if NewRelVer.Dir is a package of a lerna
+// monorepo with independently versioned packages and no TagPrefix is set.
+//
+// lerna.json is looked for in NewRelVer.Dir and its parent directories up to the root of the git repo, and only if NewRelVer.Dir has a package.json.
+func (r NewRelVer) lernaTagPrefix() (NewRelVer, error) {
+	if r.TagPrefix != "" {
+		return r, nil
+	}
+	file, err := r.FindVersionFile("package.json")
+	if err != nil {
+		return r, nil
+	}
+	dir, err := filepath.Abs(r.Dir)
+	if err != nil {
+		return r, err
+	}
+	for {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "lerna.json"))
+		if err == nil {
+			var lerna struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(data, &lerna); err != nil {
+				return r, fmt.Errorf("invalid lerna.json in %s: %v", dir, err)
+			}
+			if lerna.Version != LernaIndependent {
+				return r, nil
+			}
+			break
+		} else if !os.IsNotExist(err) {
+			return r, err
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil || dir == filepath.Dir(dir) {
+			// Stop at the root of the git repo
+			return r, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(file, &pkg); err != nil || pkg.Name == "" {
+		return r, fmt.Errorf("lerna packages are versioned independently but no package name found in %s", filepath.Join(r.Dir, "package.json"))
+	}
+	r.TagPrefix = pkg.Name + "@"
+	if r.Debug {
+		fmt.Printf("using lerna tag prefix %s\n", r.TagPrefix)
+	}
+	return r, nil
+}
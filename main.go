@@ -26,7 +26,24 @@ func main() {
 	dir := flag.String("directory", ".", "Directory of git project.")
 	baseVersion := flag.String("base-version", "", "Version to use instead of version file.")
 	sameRelease := flag.Bool("same-release", false, "Increment the latest base version release ignoring any releases higher than the base version release.")
+	constraint := flag.String("constraint", "", "Increment the latest release satisfying a semver constraint, e.g. ~1.4, \">=2.0 <3\" or 1.x, ignoring any other releases.")
 	minor := flag.Bool("minor", false, "Increment minor version instead of patch.")
+	revision := flag.Bool("revision", false, "Increment the package revision instead of the upstream version (debian and rpm schemes).")
+	branchPreRelease := flag.Bool("branch-prerelease", false, "Append a pre-release identifier for the current branch when it is not the default branch.")
+	branch := flag.String("branch", "", "Branch to use instead of detecting it from CI env vars or the local git repo.")
+	defaultBranch := flag.String("default-branch", "main", "Branch that produces releases without a pre-release identifier.")
+	releaseBranch := flag.Bool("release-branch", false, "On a release branch, e.g. release/1.4, increment that release as if -base-version 1.4 -same-release were passed.")
+	releaseBranchPattern := flag.String("release-branch-pattern", DefaultReleaseBranchPattern, "Regex for release branch names, where the version group is the release version.")
+	metadata := flag.String("metadata", "", "Append build metadata from the local git repo: sha, build (commit count) or describe (commits since latest tag).")
+	scheme := flag.String("scheme", "semver", "Versioning scheme: semver, calver, pep440, maven, debian or rpm.")
+	calVerFormat := flag.String("calver-format", DefaultCalVerFormat, "CalVer format, e.g. YYYY.MM.MICRO, YY.0M.MICRO or YYYY.WW.")
+	chartVersionKey := flag.String("chart-version-key", ChartVersion, "Chart.yaml key to read the version from: version or appVersion.")
+	goVersionFile := flag.String("go-version-file", "", "Go source file, relative to the directory, to read the version const or var from.  The new version is checked against go.mod's /vN module path suffix.")
+	goVersionName := flag.String("go-version-name", "", "Name of the Go version const or var (default Version or version).")
+	tagPrefix := flag.String("tag-prefix", "", "Only use git tags with this prefix, e.g. my-pkg@ or my-module/v.  Defaults to the package name and @ in lerna monorepos with independent versions.")
+	dockerfile := flag.String("dockerfile", "", "Dockerfile or Containerfile, relative to the directory, to read the version LABEL or ARG from, e.g. docker/app.Dockerfile.")
+	dockerVersionArg := flag.String("docker-version-arg", DefaultDockerVersionArg, "Dockerfile ARG to read the version from when there is no version LABEL.")
+	versionCode := flag.Bool("version-code", false, "Also print the next integer version code of an Android or iOS app, after the version and a space.")
 	fetch := flag.Bool("git-fetch", true, "Fetch tags from remote.")
 	owner := flag.String("gh-owner", "", "GitHub repository owner to fetch tags from instead of the local git repo.")
 	repo := flag.String("gh-repository", "", "GitHub repository to fetch tags from instead of the local git repo.")
@@ -57,12 +74,33 @@ func main() {
 		gitClient = NewLocalGitClient(*dir, *fetch, *debug)
 	}
 
+	versionScheme, err := NewVersionScheme(*scheme, *calVerFormat)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(-1)
+	}
+
 	r := NewRelVer{
-		Dir:         *dir,
-		BaseVersion: *baseVersion,
-		SameRelease: *sameRelease,
-		Minor:       *minor,
-		Debug:       *debug,
+		Dir:                  *dir,
+		BaseVersion:          *baseVersion,
+		SameRelease:          *sameRelease,
+		Constraint:           *constraint,
+		Minor:                *minor,
+		Revision:             *revision,
+		BranchPreRelease:     *branchPreRelease,
+		Branch:               *branch,
+		DefaultBranch:        *defaultBranch,
+		ReleaseBranch:        *releaseBranch,
+		ReleaseBranchPattern: *releaseBranchPattern,
+		Metadata:             *metadata,
+		ChartVersionKey:      *chartVersionKey,
+		GoVersionFile:        *goVersionFile,
+		GoVersionName:        *goVersionName,
+		TagPrefix:            *tagPrefix,
+		Dockerfile:           *dockerfile,
+		DockerVersionArg:     *dockerVersionArg,
+		Scheme:               versionScheme,
+		Debug:                *debug,
 	}
 
 	v, err := r.GetNewVersion(gitClient)
@@ -70,5 +108,14 @@ func main() {
 		fmt.Printf("failed to get new version: %v\n", err)
 		os.Exit(-1)
 	}
-	fmt.Print(v.String())
+	if !*versionCode {
+		fmt.Print(versionScheme.Format(v))
+		return
+	}
+	code, err := r.GetNewVersionCode(gitClient)
+	if err != nil {
+		fmt.Printf("failed to get new version code: %v\n", err)
+		os.Exit(-1)
+	}
+	fmt.Printf("%s %d", versionScheme.Format(v), code)
 }
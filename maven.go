@@ -0,0 +1,351 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Maven qualifiers in order, where "" is a release, see https://maven.apache.org/pom.html#version-order-specification.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenQualifierAliases maps qualifiers to the qualifier they are equal to.
+var mavenQualifierAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// mavenReleaseQualifier is the comparable form of the "" qualifier.
+var mavenReleaseQualifier = mavenComparableQualifier("")
+
+var mavenVersionRegex = regexp.MustCompile(`^[vV]?(\d+(?:\.\d+)*)(.*)$`)
+
+// mavenItem is an item of a parsed Maven version.  A nil mavenItem is compared as a missing item, like Maven's null item.
+type mavenItem interface {
+	compare(o mavenItem) int
+	isNull() bool
+}
+
+// mavenInt is a numeric item without leading zeros.
+type mavenInt string
+
+func (i mavenInt) isNull() bool {
+	return i == "0"
+}
+
+func (i mavenInt) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case mavenInt:
+		if c := compareInt(len(i), len(o)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(i), string(o))
+	}
+	// Numbers are newer than qualifiers and lists
+	return 1
+}
+
+// mavenString is a qualifier item with aliases resolved.
+type mavenString string
+
+func newMavenString(s string, followedByDigit bool) mavenString {
+	if followedByDigit && len(s) == 1 {
+		// a1 = alpha-1, b1 = beta-1, m1 = milestone-1
+		switch s {
+		case "a":
+			s = "alpha"
+		case "b":
+			s = "beta"
+		case "m":
+			s = "milestone"
+		}
+	}
+	if alias, ok := mavenQualifierAliases[s]; ok {
+		s = alias
+	}
+	return mavenString(s)
+}
+
+// mavenComparableQualifier returns a string that sorts known qualifiers in order, followed by unknown qualifiers in lexical order.
+func mavenComparableQualifier(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return strconv.Itoa(i)
+		}
+	}
+	return fmt.Sprintf("%d-%s", len(mavenQualifiers), q)
+}
+
+func (s mavenString) isNull() bool {
+	return s == ""
+}
+
+func (s mavenString) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		return strings.Compare(mavenComparableQualifier(string(s)), mavenReleaseQualifier)
+	case mavenString:
+		return strings.Compare(mavenComparableQualifier(string(s)), mavenComparableQualifier(string(o)))
+	case mavenInt:
+		return -1
+	}
+	// Qualifiers are older than lists
+	return -1
+}
+
+// mavenList is a list of items, started by a - separator or a change between digits and letters.
+type mavenList []mavenItem
+
+func (l mavenList) isNull() bool {
+	return len(l) == 0
+}
+
+func (l mavenList) compare(o mavenItem) int {
+	switch o := o.(type) {
+	case nil:
+		for _, i := range l {
+			if c := i.compare(nil); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case mavenInt:
+		return -1
+	case mavenString:
+		return 1
+	case mavenList:
+		for i := 0; i < len(l) || i < len(o); i++ {
+			var li, ri mavenItem
+			if i < len(l) {
+				li = l[i]
+			}
+			if i < len(o) {
+				ri = o[i]
+			}
+			var c int
+			switch {
+			case li == nil && ri == nil:
+				c = 0
+			case li == nil:
+				c = -ri.compare(li)
+			default:
+				c = li.compare(ri)
+			}
+			if c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// normalize removes trailing null items, stopping at the first item that is not null or a list.
+func (l *mavenList) normalize() {
+	for i := len(*l) - 1; i >= 0; i-- {
+		item := (*l)[i]
+		if item.isNull() {
+			*l = append((*l)[:i], (*l)[i+1:]...)
+		} else if _, ok := item.(*mavenList); !ok {
+			break
+		}
+	}
+}
+
+// parseMavenItems parses a version the same way as Maven's ComparableVersion.
+func parseMavenItems(version string) mavenList {
+	version = strings.ToLower(version)
+
+	root := &mavenList{}
+	list := root
+	stack := []*mavenList{root}
+	push := func() {
+		sub := &mavenList{}
+		*list = append(*list, sub)
+		list = sub
+		stack = append(stack, sub)
+	}
+	item := func(isDigit bool, s string) mavenItem {
+		if isDigit {
+			n := strings.TrimLeft(s, "0")
+			if n == "" {
+				n = "0"
+			}
+			return mavenInt(n)
+		}
+		return newMavenString(s, false)
+	}
+
+	isDigit := false
+	start := 0
+	for i, c := range version {
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				*list = append(*list, mavenInt("0"))
+			} else {
+				*list = append(*list, item(isDigit, version[start:i]))
+			}
+			start = i + 1
+			if c == '-' {
+				push()
+			}
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				// Treat .X as -X for any qualifier X, so 1.0.0.X1 < 1.0.0-X2
+				if len(*list) > 0 {
+					push()
+				}
+				*list = append(*list, newMavenString(version[start:i], true))
+				start = i
+				push()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				*list = append(*list, item(true, version[start:i]))
+				start = i
+				push()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		if !isDigit && len(*list) > 0 {
+			push()
+		}
+		*list = append(*list, item(isDigit, version[start:]))
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return derefMavenLists(*root)
+}
+
+// derefMavenLists replaces the *mavenList items used while parsing with mavenList values.
+func derefMavenLists(l mavenList) mavenList {
+	rv := make(mavenList, len(l))
+	for i, item := range l {
+		if sub, ok := item.(*mavenList); ok {
+			item = derefMavenLists(*sub)
+		}
+		rv[i] = item
+	}
+	return rv
+}
+
+// MavenVersion is a Maven artifact version, such as 1.2.3.4, 1.2.3-SNAPSHOT or 1.2.3.RELEASE.
+type MavenVersion struct {
+	original string
+	items    mavenList
+}
+
+// NewMavenVersion parses a Maven version.  The version must start with a number, optionally prefixed with v.
+func NewMavenVersion(v string) (*MavenVersion, error) {
+	if !mavenVersionRegex.MatchString(v) {
+		return nil, fmt.Errorf("invalid Maven version %q", v)
+	}
+	v = strings.TrimLeft(v, "vV")
+	return &MavenVersion{original: v, items: parseMavenItems(v)}, nil
+}
+
+// String returns the version as it was given, without any v prefix.
+func (v *MavenVersion) String() string {
+	return v.original
+}
+
+// Compare compares this version to another version.  This returns -1, 0, or 1 if this version is smaller, equal, or larger than the other version.
+//
+// Versions are ordered like Maven's ComparableVersion, e.g. 1.2-SNAPSHOT < 1.2 = 1.2.0 = 1.2.RELEASE < 1.2-sp < 1.2.0.1.
+func (v *MavenVersion) Compare(o *MavenVersion) int {
+	return v.items.compare(o.items)
+}
+
+// numbers returns the leading numeric components and the remaining qualifier.
+func (v *MavenVersion) numbers() ([]int, string) {
+	m := mavenVersionRegex.FindStringSubmatch(v.original)
+	var nums []int
+	for _, n := range strings.Split(m[1], ".") {
+		i, _ := strconv.Atoi(n)
+		nums = append(nums, i)
+	}
+	return nums, m[2]
+}
+
+// MavenScheme is a VersionScheme for Maven artifact versions that orders versions like Maven's ComparableVersion.
+//
+// Versions are *MavenVersion structs.
+type MavenScheme struct{}
+
+// Parse converts a version string into a *MavenVersion.
+func (MavenScheme) Parse(v string) (Version, error) {
+	mv, err := NewMavenVersion(v)
+	if err != nil {
+		return nil, err
+	}
+	return mv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func (MavenScheme) Compare(a, b Version) int {
+	return a.(*MavenVersion).Compare(b.(*MavenVersion))
+}
+
+// Bump increments the last numeric component, or the minor component, of v.
+//
+// Versions are padded to at least three components and a fourth component is preserved.  Release qualifiers like .RELEASE or .Final are kept while
+// pre-release qualifiers like -SNAPSHOT or -rc1 are dropped.
+//
+// E.g. 1.2.3.4 is incremented to 1.2.3.5 (or 1.3.0.0 for a minor increment), 1.2.3.RELEASE to 1.2.4.RELEASE and 1.2.3-SNAPSHOT to 1.2.4.
+func (MavenScheme) Bump(v Version, part BumpPart) (Version, error) {
+	nums, qualifier := v.(*MavenVersion).numbers()
+	for len(nums) < 3 {
+		nums = append(nums, 0)
+	}
+	switch part {
+	case BumpPatch:
+		nums[len(nums)-1]++
+	case BumpMinor:
+		nums[1]++
+		for i := 2; i < len(nums); i++ {
+			nums[i] = 0
+		}
+	default:
+		return nil, fmt.Errorf("%s increment is not supported by the maven scheme", part)
+	}
+
+	fields := make([]string, len(nums))
+	for i, n := range nums {
+		fields[i] = strconv.Itoa(n)
+	}
+	next := strings.Join(fields, ".")
+	if q := strings.TrimLeft(qualifier, ".-"); q != "" && newMavenString(strings.ToLower(q), false).isNull() {
+		next += qualifier
+	}
+	return &MavenVersion{original: next, items: parseMavenItems(next)}, nil
+}
+
+// Format returns the string form of v.
+func (MavenScheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns 0.0.0.
+func (MavenScheme) Zero() Version {
+	return &MavenVersion{original: "0.0.0", items: parseMavenItems("0.0.0")}
+}
+
+// SameRelease returns true if a and b share the same major and minor version numbers.
+func (MavenScheme) SameRelease(a, b Version) bool {
+	an, _ := a.(*MavenVersion).numbers()
+	bn, _ := b.(*MavenVersion).numbers()
+	an, bn = append(an, 0), append(bn, 0)
+	return an[0] == bn[0] && an[1] == bn[1]
+}
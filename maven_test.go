@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Version orders from Maven's ComparableVersionTest.
+var mavenQualifierOrder = []string{
+	"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2", "1-rc123", "1-SNAPSHOT", "1", "1-sp",
+	"1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot", "1-1", "1-2", "1-123",
+}
+
+var mavenNumberOrder = []string{
+	"2.0", "2.0.a", "2-1", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1", "2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11",
+	"11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m",
+}
+
+func assertMavenOrder(t *testing.T, ordered []string) {
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, err := NewMavenVersion(ordered[i])
+			assert.NoError(t, err)
+			b, err := NewMavenVersion(ordered[j])
+			assert.NoError(t, err)
+			assert.Equal(t, compareInt(i, j), a.Compare(b), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestMavenVersionOrder(t *testing.T) {
+	assertMavenOrder(t, mavenQualifierOrder)
+	assertMavenOrder(t, mavenNumberOrder)
+}
+
+func TestMavenVersionEqual(t *testing.T) {
+	for _, v := range []string{"1", "1.0", "1.0.0", "1-0", "1.0.RELEASE", "1-final", "1.ga", "v1.0"} {
+		a, _ := NewMavenVersion("1")
+		b, err := NewMavenVersion(v)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, a.Compare(b), v)
+	}
+}
+
+func TestMavenSchemeBump(t *testing.T) {
+	s := MavenScheme{}
+	for v, next := range map[string][2]string{
+		"1.2.3.4":        {"1.2.3.5", "1.3.0.0"},
+		"1.2.3-SNAPSHOT": {"1.2.4", "1.3.0"},
+		"1.2.3.RELEASE":  {"1.2.4.RELEASE", "1.3.0.RELEASE"},
+		"1.2":            {"1.2.1", "1.3.0"},
+	} {
+		mv, err := s.Parse(v)
+		assert.NoError(t, err)
+
+		patch, err := s.Bump(mv, BumpPatch)
+		assert.NoError(t, err)
+		minor, err := s.Bump(mv, BumpMinor)
+		assert.NoError(t, err)
+
+		assert.Equal(t, next[0], s.Format(patch), v)
+		assert.Equal(t, next[1], s.Format(minor), v)
+	}
+}
+
+func TestGetNewVersionMaven(t *testing.T) {
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: MavenScheme{},
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.3.9", "v1.2.3.10", "v1.2.3", "v1.2.3.10-SNAPSHOT"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3.11", v.String())
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Build metadata modes for NewRelVer.Metadata.
+const (
+	// MetadataSHA appends the abbreviated commit hash, e.g. 1.2.4+sha.1a2b3c4.
+	MetadataSHA = "sha"
+	// MetadataBuild appends the number of commits in the current branch, e.g. 1.2.4+build.123.
+	MetadataBuild = "build"
+	// MetadataDescribe appends the number of commits since the latest version tag and the abbreviated commit hash, like `git describe`, e.g.
+	// 1.2.4-dev.7+g1a2b3c4.
+	MetadataDescribe = "describe"
+)
+
+// buildMetadata returns v with build metadata for the HEAD commit of the local git repo according to NewRelVer.Metadata.
+//
+// latestTag is the tag of the version v was incremented from, or empty if there is none.  It is used to count the commits since the latest release.
+func (r NewRelVer) buildMetadata(v *semver.Version, latestTag string) (*semver.Version, error) {
+	switch r.Metadata {
+	case "":
+		return v, nil
+	case MetadataSHA:
+		sha, err := runGit(r.Dir, "rev-parse", "--short=7", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		v.Metadata = "sha." + sha
+	case MetadataBuild:
+		count, err := runGit(r.Dir, "rev-list", "--count", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		v.Metadata = "build." + count
+	case MetadataDescribe:
+		revs := "HEAD"
+		if latestTag != "" {
+			revs = latestTag + "..HEAD"
+		}
+		count, err := runGit(r.Dir, "rev-list", "--count", revs)
+		if err != nil {
+			return nil, err
+		}
+		sha, err := runGit(r.Dir, "rev-parse", "--short=7", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		pre := []string{"dev", count}
+		if v.PreRelease != "" {
+			pre = append([]string{string(v.PreRelease)}, pre...)
+		}
+		v.PreRelease = semver.PreRelease(strings.Join(pre, "."))
+		v.Metadata = "g" + sha
+	default:
+		return nil, fmt.Errorf("unknown build metadata mode %q", r.Metadata)
+	}
+	if r.Debug {
+		fmt.Printf("added build metadata: %s\n", v)
+	}
+	return v, nil
+}
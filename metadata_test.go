@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates a git repo with a v1.0.0 tag followed by two more commits and returns its directory.
+func newTestRepo(t *testing.T) string {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "one"},
+		{"tag", "v1.0.0"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "two"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "three"},
+	} {
+		_, err := runGit(dir, args...)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func TestGetNewVersionMetadataSHA(t *testing.T) {
+	dir := newTestRepo(t)
+	sha, err := runGit(dir, "rev-parse", "--short=7", "HEAD")
+	require.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:      dir,
+		Metadata: MetadataSHA,
+	}
+
+	v, err := r.GetNewVersion(NewLocalGitClient(dir, false, false))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.1+sha."+sha, v.String())
+}
+
+func TestGetNewVersionMetadataBuild(t *testing.T) {
+	dir := newTestRepo(t)
+
+	r := NewRelVer{
+		Dir:      dir,
+		Metadata: MetadataBuild,
+	}
+
+	v, err := r.GetNewVersion(NewLocalGitClient(dir, false, false))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.1+build.3", v.String())
+}
+
+func TestGetNewVersionMetadataDescribe(t *testing.T) {
+	dir := newTestRepo(t)
+	sha, err := runGit(dir, "rev-parse", "--short=7", "HEAD")
+	require.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:      dir,
+		Metadata: MetadataDescribe,
+	}
+
+	v, err := r.GetNewVersion(NewLocalGitClient(dir, false, false))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.1-dev.2+g"+sha, v.String())
+}
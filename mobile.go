@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"howett.net/plist"
+)
+
+// Version identifier regex strings for Android and Xcode projects.
+//
+// The %s is replaced with VersionNumberRegex.
+const (
+	AndroidVersionNameRegexf = `(?m)^\s*versionName\s*=?\s*['"](%s)['"]`
+	XcodeBuildSettingRegexf  = `(?m)^\s*%s\s*=\s*"?([^";]+)"?;`
+)
+
+var (
+	androidVersionCodeRegex   = regexp.MustCompile(`(?m)^\s*versionCode\s*=?\s*(\d+)`)
+	xcodeVariableRegex        = regexp.MustCompile(`^\$[({](\w+)[)}]$`)
+	androidDefaultConfigRegex = regexp.MustCompile(`\bdefaultConfig\s*\{`)
+)
+
+// xcodeProject is the pattern of Xcode project files in NewRelVer.Dir.
+const xcodeProject = "*.xcodeproj/project.pbxproj"
+
+// infoPlistFiles are the patterns of iOS and macOS app Info.plist files in NewRelVer.Dir.
+var infoPlistFiles = []string{"Info.plist", "*/Info.plist"}
+
+// androidDefaultConfig returns the contents of the defaultConfig { ... } block of an Android build.gradle or build.gradle.kts file, or nil if there is
+// none, so that versions of product flavors or build types are ignored.
+func androidDefaultConfig(data []byte) []byte {
+	loc := androidDefaultConfigRegex.FindIndex(data)
+	if loc == nil {
+		return nil
+	}
+	depth := 1
+	var quote byte
+	for i := loc[1]; i < len(data); i++ {
+		switch c := data[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			if end := bytes.Index(data[i+2:], []byte("*/")); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(data)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return data[loc[1]:i]
+			}
+		}
+	}
+	return nil
+}
+
+func (r NewRelVer) findInfoPlistVersion(data []byte) (string, error) {
+	return r.infoPlistValue(data, "CFBundleShortVersionString")
+}
+
+// infoPlistValue returns the value of a key in an Info.plist file, resolving build setting variables such as $(MARKETING_VERSION) from the Xcode
+// project.
+func (r NewRelVer) infoPlistValue(data []byte, key string) (string, error) {
+	var info map[string]interface{}
+	if _, err := plist.Unmarshal(data, &info); err != nil {
+		return "0.0.0", fmt.Errorf("invalid Info.plist: %v", err)
+	}
+	v, ok := info[key].(string)
+	if !ok || v == "" {
+		return "0.0.0", fmt.Errorf("No %s found", key)
+	}
+	if m := xcodeVariableRegex.FindStringSubmatch(v); m != nil {
+		return r.xcodeBuildSetting(m[1])
+	}
+	return v, nil
+}
+
+// xcodeBuildSetting returns the first value of a build setting, such as MARKETING_VERSION, in the Xcode project in NewRelVer.Dir.
+func (r NewRelVer) xcodeBuildSetting(name string) (string, error) {
+	regex := fmt.Sprintf(XcodeBuildSettingRegexf, regexp.QuoteMeta(name))
+	for _, f := range r.versionFileNames(xcodeProject) {
+		if data, err := r.FindVersionFile(f); err == nil {
+			if v, err := matchVersion(data, regex, 1); err == nil {
+				return v, nil
+			}
+		}
+	}
+	return "0.0.0", fmt.Errorf("No %s build setting found", name)
+}
+
+// currentVersionCode returns the defaultConfig versionCode of an Android app's build.gradle or build.gradle.kts file, or the CFBundleVersion of an app's
+// Info.plist file, or 0 if there is none.
+func (r NewRelVer) currentVersionCode() int {
+	for _, f := range []string{"build.gradle", "build.gradle.kts"} {
+		if data, err := r.FindVersionFile(f); err == nil {
+			if m := androidVersionCodeRegex.FindSubmatch(androidDefaultConfig(data)); m != nil {
+				code, _ := strconv.Atoi(string(m[1]))
+				return code
+			}
+		}
+	}
+	for _, pattern := range infoPlistFiles {
+		for _, f := range r.versionFileNames(pattern) {
+			if data, err := r.FindVersionFile(f); err == nil {
+				if v, err := r.infoPlistValue(data, "CFBundleVersion"); err == nil {
+					if code, err := strconv.Atoi(v); err == nil {
+						return code
+					}
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// GetNewVersionCode returns the next integer version code of a mobile app, such as an Android versionCode or an iOS CFBundleVersion, which must increase
+// with every release.
+//
+// The current version code is taken to be the code of the version in the version file, so the next code is the current code plus the number of version
+// tags higher than that version, plus one.  E.g. with versionCode 57 and versionName 1.2.3, tags v1.2.3 and v1.2.4 give 59.  Codes keep increasing with
+// every release whether or not the version file is updated after a release.  If there is no current version code then the version tags are counted.
+func (r NewRelVer) GetNewVersionCode(gitClient GitClient) (int, error) {
+	r, err := r.lernaTagPrefix()
+	if err != nil {
+		return 0, err
+	}
+	tags, err := r.listTags(gitClient)
+	if err != nil {
+		return 0, err
+	}
+
+	current := r.currentVersionCode()
+	since := r.scheme().Zero()
+	if current > 0 {
+		// The version code belongs to the version file's version, not NewRelVer.BaseVersion
+		fileVersion := r
+		fileVersion.BaseVersion = ""
+		if since, err = fileVersion.GetBaseVersion(); err != nil {
+			return 0, err
+		}
+	}
+	count := r.countNewerTags(tags, since)
+	if r.Debug {
+		fmt.Printf("found version code %d and %d version tags since %s\n", current, count, r.scheme().Format(since))
+	}
+	return current + count + 1, nil
+}
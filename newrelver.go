@@ -2,20 +2,25 @@ package main
 
 import (
 	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/coreos/go-semver/semver"
 	goVersion "github.com/hashicorp/go-version"
+	"gopkg.in/yaml.v3"
 )
 
 // VersionNumberRegex is the regex used to find a version number.
-const VersionNumberRegex = `[\.\d]+(-\w+)?`
+//
+// It matches semver versions like 1.2.3-SNAPSHOT as well as other schemes' versions like 1.2.0rc1, 1.2.0.post1 or 1!2.0.
+const VersionNumberRegex = `[\.\d]+(?:[-+!]?\w[.\w]*)?`
 
 // Version identifier regex strings for version files.
 //
@@ -27,25 +32,67 @@ const (
 	SetupPyRegexf        = `(?ms)setup\(.*\s+version\s*=\s*['"](%s)['"].*\)$`
 	CMakeListsTxtRegexf  = `(?ms)^project\s*\(.*\s+VERSION\s+(%s).*\)$`
 	MakefileRegexf       = `(?m)^VERSION\s*:=\s*(%s)$`
+	BuildSbtRegexf       = `(?m)^\s*(?:ThisBuild\s*/\s*)?version\s*:=\s*"(%s)"`
+	ProjectCljRegexf     = `\(defproject\s+\S+\s+"(%s)"`
+	ConfigureAcRegexf    = `AC_INIT\(\s*\[?[^,]*?\]?\s*,\s*\[?\s*(%s)\s*\]?`
+	MesonBuildRegexf     = `\bproject\s*\([^)]*?\bversion\s*:\s*'(%s)'`
+	ModuleBazelRegexf    = `\bmodule\s*\([^)]*?\bversion\s*=\s*['"](%s)['"]`
+	PlainVersionRegexf   = `^v?(%s)$`
+	CabalRegexf          = `(?mi)^version\s*:\s*(%s)\s*$`
+	DescriptionRegexf    = `(?m)^Version:\s*(%s)\s*$`
 )
 
-type findVersion func([]byte) (string, error)
+type findVersion func(NewRelVer, []byte) (string, error)
 
 var versionFiles = map[string]findVersion{
-	"gradle.properties": versionMatcher(ConfigRegexf, 1),
-	"build.gradle":      versionMatcher(BuildGradleRegexf, 1),
-	"build.gradle.kts":  versionMatcher(BuildGradleRegexf, 1),
-	"versions.gradle":   versionMatcher(VersionsGradleRegexf, 1),
-	"pom.xml":           unmarshalXMLVersion,
-	"package.json":      unmarshalJSONVersion,
-	"setup.cfg":         versionMatcher(ConfigRegexf, 1),
-	"setup.py":          versionMatcher(SetupPyRegexf, 1),
-	"CMakeLists.txt":    versionMatcher(CMakeListsTxtRegexf, 1),
-	"Makefile":          versionMatcher(MakefileRegexf, 1),
+	"gradle.properties":     versionMatcher(ConfigRegexf, 1),
+	"build.gradle":          NewRelVer.findGradleVersion,
+	"build.gradle.kts":      NewRelVer.findGradleVersion,
+	"versions.gradle":       versionMatcher(VersionsGradleRegexf, 1),
+	"pom.xml":               NewRelVer.unmarshalPomVersion,
+	"package.json":          unmarshalJSONVersion,
+	"setup.cfg":             NewRelVer.findSetupCfgVersion,
+	"setup.py":              versionMatcher(SetupPyRegexf, 1),
+	"CMakeLists.txt":        versionMatcher(CMakeListsTxtRegexf, 1),
+	"Makefile":              versionMatcher(MakefileRegexf, 1),
+	"Cargo.toml":            NewRelVer.unmarshalCargoVersion,
+	"pyproject.toml":        NewRelVer.unmarshalPyprojectVersion,
+	"Chart.yaml":            NewRelVer.unmarshalChartVersion,
+	"*.csproj":              unmarshalMSBuildVersion,
+	"Directory.Build.props": unmarshalMSBuildVersion,
+	"*.nuspec":              unmarshalNuspecVersion,
+	"*.gemspec":             NewRelVer.findGemspecVersion,
+	"lib/*/version.rb":      versionMatcher(RubyVersionRegexf, 1),
+	"composer.json":         unmarshalJSONVersion,
+	"pubspec.yaml":          unmarshalYAMLVersion,
+	"build.sbt":             versionMatcher(BuildSbtRegexf, 1),
+	"project.clj":           versionMatcher(ProjectCljRegexf, 1),
+	"configure.ac":          versionMatcher(ConfigureAcRegexf, 1),
+	"meson.build":           versionMatcher(MesonBuildRegexf, 1),
+	"MODULE.bazel":          versionMatcher(ModuleBazelRegexf, 1),
+	"VERSION":               unmarshalPlainVersion,
+	"VERSION.txt":           unmarshalPlainVersion,
+	"version.txt":           unmarshalPlainVersion,
+	"mix.exs":               findMixVersion,
+	"*.cabal":               versionMatcher(CabalRegexf, 1),
+	"DESCRIPTION":           versionMatcher(DescriptionRegexf, 1),
+	"Project.toml":          unmarshalTOMLVersion,
+	"lerna.json":            unmarshalLernaVersion,
+	"deno.json":             unmarshalJSONCVersion,
+	"deno.jsonc":            unmarshalJSONCVersion,
+	"jsr.json":              unmarshalJSONVersion,
+	"Info.plist":            NewRelVer.findInfoPlistVersion,
+	"*/Info.plist":          NewRelVer.findInfoPlistVersion,
+}
+
+// fallbackVersionFiles are only read when none of the versionFiles hold a version, as they are often found beside a project's real version file.
+var fallbackVersionFiles = map[string]findVersion{
+	"Dockerfile":    NewRelVer.findDockerfileVersion,
+	"Containerfile": NewRelVer.findDockerfileVersion,
 }
 
 func versionMatcher(regexf string, group int) findVersion {
-	return func(file []byte) (string, error) {
+	return func(_ NewRelVer, file []byte) (string, error) {
 		regex := fmt.Sprintf(regexf, VersionNumberRegex)
 		return matchVersion(file, regex, group)
 	}
@@ -61,7 +108,31 @@ func matchVersion(data []byte, regex string, group int) (string, error) {
 	return "0.0.0", errors.New("No version found")
 }
 
-func unmarshalJSONVersion(data []byte) (string, error) {
+// unmarshalPlainVersion returns the first line of a file that only holds a version number, ignoring blank lines and # comments.
+func unmarshalPlainVersion(_ NewRelVer, data []byte) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return matchVersion([]byte(line), fmt.Sprintf(PlainVersionRegexf, VersionNumberRegex), 1)
+		}
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+func unmarshalTOMLVersion(_ NewRelVer, data []byte) (string, error) {
+	var project struct {
+		Version string `toml:"version"`
+	}
+	toml.Decode(string(data), &project)
+	if project.Version != "" {
+		return project.Version, nil
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+func unmarshalJSONVersion(_ NewRelVer, data []byte) (string, error) {
 	var project struct {
 		Version string `json:"version"`
 	}
@@ -72,11 +143,11 @@ func unmarshalJSONVersion(data []byte) (string, error) {
 	return "0.0.0", errors.New("No version found")
 }
 
-func unmarshalXMLVersion(data []byte) (string, error) {
+func unmarshalYAMLVersion(_ NewRelVer, data []byte) (string, error) {
 	var project struct {
-		Version string `xml:"version"`
+		Version string `yaml:"version"`
 	}
-	xml.Unmarshal(data, &project)
+	yaml.Unmarshal(data, &project)
 	if project.Version != "" {
 		return project.Version, nil
 	}
@@ -100,12 +171,37 @@ func NewSemVer(v string) (*semver.Version, error) {
 }
 
 // NewRelVer is the release version config.
+//
+// Versions are parsed, compared and incremented by the Scheme, or by SemVerScheme if it is not set.
 type NewRelVer struct {
-	Dir         string
-	BaseVersion string
-	SameRelease bool
-	Minor       bool
-	Debug       bool
+	Dir                  string
+	BaseVersion          string
+	SameRelease          bool
+	Constraint           string
+	Minor                bool
+	Revision             bool
+	BranchPreRelease     bool
+	Branch               string
+	DefaultBranch        string
+	ReleaseBranch        bool
+	ReleaseBranchPattern string
+	Metadata             string
+	ChartVersionKey      string
+	GoVersionFile        string
+	GoVersionName        string
+	TagPrefix            string
+	Dockerfile           string
+	DockerVersionArg     string
+	Scheme               VersionScheme
+	Debug                bool
+}
+
+// scheme returns NewRelVer.Scheme, or SemVerScheme if it is not set.
+func (r NewRelVer) scheme() VersionScheme {
+	if r.Scheme == nil {
+		return SemVerScheme{}
+	}
+	return r.Scheme
 }
 
 // GetNewVersion returns an incremented version number based on the current latest version.
@@ -117,28 +213,109 @@ type NewRelVer struct {
 // - If a project has no previous versions but has set a base version of 1.0, then 1.0.0 is returned.
 //
 // - For projects that have no previous versions or base version, then 0.0.1 is returned (or 0.1.0 if NewRelVer.minor is set to true).
-func (r NewRelVer) GetNewVersion(gitClient GitClient) (*semver.Version, error) {
-	newVersion, baseVersion, err := r.GetLatestVersion(gitClient)
+//
+// - If NewRelVer.Constraint is set, then the latest version satisfying the constraint is incremented, e.g. 1.4.3 becomes 1.4.4 for ~1.4 even if there is a
+// 2.0.0 release.  An error is returned if the new version does not satisfy the constraint.
+//
+// - If NewRelVer.ReleaseBranch is set and the current branch is a release branch like release/1.4, then the latest 1.4 version is incremented as if
+// NewRelVer.BaseVersion were 1.4 and NewRelVer.SameRelease were set.
+//
+// - If NewRelVer.Revision is set, then the package revision is incremented instead, e.g. 1.2.0-1 becomes 1.2.0-2 with the debian scheme.
+//
+// - If NewRelVer.BranchPreRelease is set and the current branch is not NewRelVer.DefaultBranch, then a pre-release identifier for the branch is appended,
+// e.g. 1.2.1-feature-login.1.
+//
+// - If NewRelVer.Metadata is set, then build metadata from the local git repo is added, e.g. 1.2.1+sha.1a2b3c4 or 1.2.1-dev.7+g1a2b3c4.
+func (r NewRelVer) GetNewVersion(gitClient GitClient) (Version, error) {
+	r, err := r.releaseBranch()
+	if err != nil {
+		return nil, err
+	}
+	r, err = r.lernaTagPrefix()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.listTags(gitClient)
+	if err != nil {
+		return nil, err
+	}
+	latestVersion, baseVersion, err := r.latestVersion(tags)
 	if err != nil {
 		return nil, err
 	}
 
-	if newVersion == nil {
-		// Return the new base version as is unless it is 0.0.0, in which case we should increment to 0.0.1
-		if !baseVersion.Equal(semver.Version{}) {
-			return baseVersion, nil
+	scheme := r.scheme()
+	if latestVersion == nil {
+		// Use the new base version as is unless it is zero, e.g. 0.0.0, in which case we should increment to 0.0.1
+		if scheme.Compare(baseVersion, scheme.Zero()) != 0 {
+			if err := r.checkNewVersion(baseVersion); err != nil {
+				return nil, err
+			}
+			return r.decorate(baseVersion, tags, "")
 		}
-		newVersion = baseVersion
+		latestVersion = baseVersion
 	}
 
 	// Increment version
-	if r.Minor {
-		newVersion.BumpMinor()
-	} else {
-		newVersion.BumpPatch()
+	part := BumpPatch
+	if r.Revision {
+		part = BumpRevision
+	} else if r.Minor {
+		part = BumpMinor
+	}
+	newVersion, err := scheme.Bump(latestVersion, part)
+	if err != nil {
+		return nil, err
+	}
+	newVersion = r.pubspecBuildNumber(newVersion, latestVersion, baseVersion, tags)
+	if err := r.checkNewVersion(newVersion); err != nil {
+		return nil, err
+	}
+
+	return r.decorate(newVersion, tags, r.findTag(tags, latestVersion))
+}
+
+// decorate adds a branch pre-release identifier and build metadata to v if NewRelVer.BranchPreRelease and NewRelVer.Metadata are set.
+//
+// latestTag is the tag of the version v was incremented from, or empty if there is none.
+func (r NewRelVer) decorate(v Version, tags []string, latestTag string) (Version, error) {
+	if !r.BranchPreRelease && r.Metadata == "" {
+		return v, nil
 	}
+	sv, ok := v.(*semver.Version)
+	if !ok {
+		return nil, errors.New("branch pre-releases and build metadata are only supported by the semver scheme")
+	}
+	if r.BranchPreRelease {
+		var err error
+		if sv, err = r.branchPreRelease(sv, tags); err != nil {
+			return nil, err
+		}
+	}
+	return r.buildMetadata(sv, latestTag)
+}
 
-	return newVersion, nil
+// findTag returns the tag for version v, or an empty string if there is no such tag.
+func (r NewRelVer) findTag(tags []string, v Version) string {
+	scheme := r.scheme()
+	for _, t := range tags {
+		if tv, _ := scheme.Parse(t); tv != nil && scheme.Compare(tv, v) == 0 {
+			return r.TagPrefix + t
+		}
+	}
+	return ""
+}
+
+// countNewerTags returns the number of version tags higher than v, i.e. the number of releases since v.
+func (r NewRelVer) countNewerTags(tags []string, v Version) int {
+	scheme := r.scheme()
+	count := 0
+	for _, t := range tags {
+		if tv, _ := scheme.Parse(t); tv != nil && scheme.Compare(tv, v) > 0 {
+			count++
+		}
+	}
+	return count
 }
 
 // GetLatestVersion returns the project's latest known version and base version.
@@ -154,29 +331,89 @@ func (r NewRelVer) GetNewVersion(gitClient GitClient) (*semver.Version, error) {
 // - If there are no git tags and no base version, then 0.0.0 will be returned.
 //
 // Note the base version is always returned (even if it is 0.0.0) unless there is an error.
-func (r NewRelVer) GetLatestVersion(gitClient GitClient) (latest, base *semver.Version, err error) {
-	baseVersion, err := r.GetBaseVersion()
+//
+// If NewRelVer.BranchPreRelease is set then pre-release tags are ignored, so branch builds do not affect the release version.
+//
+// If NewRelVer.ReleaseBranch is set and the current branch is a release branch, then the branch's version is used as the base version of the same release.
+//
+// If NewRelVer.Constraint is set then tags that do not satisfy the constraint are ignored, and if the base version does not satisfy it then 0.0.0 is returned
+// as the base version.
+func (r NewRelVer) GetLatestVersion(gitClient GitClient) (latest, base Version, err error) {
+	r, err = r.releaseBranch()
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err = r.lernaTagPrefix()
 	if err != nil {
 		return nil, nil, err
 	}
+	tags, err := r.listTags(gitClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.latestVersion(tags)
+}
 
-	// Get all tags from git
+// listTags returns all tags from git.
+//
+// If NewRelVer.TagPrefix is set, then only tags with that prefix are returned, with the prefix removed.
+func (r NewRelVer) listTags(gitClient GitClient) ([]string, error) {
 	tags, err := gitClient.ListTags()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	if r.TagPrefix != "" {
+		var prefixed []string
+		for _, t := range tags {
+			if strings.HasPrefix(t, r.TagPrefix) {
+				prefixed = append(prefixed, strings.TrimPrefix(t, r.TagPrefix))
+			}
+		}
+		tags = prefixed
 	}
 	if r.Debug {
 		fmt.Printf("found tags: %v\n", tags)
 	}
+	return tags, nil
+}
+
+// latestVersion returns the latest and base version given the project's git tags.
+func (r NewRelVer) latestVersion(tags []string) (latest, base Version, err error) {
+	baseVersion, err := r.GetBaseVersion()
+	if err != nil {
+		return nil, nil, err
+	}
 	if len(tags) == 0 {
 		return nil, baseVersion, nil
 	}
 
+	scheme := r.scheme()
+	sameRelease, ok := scheme.(SameReleaseScheme)
+	if r.SameRelease && !ok {
+		return nil, nil, errors.New("same release is not supported by the version scheme")
+	}
+	satisfies, err := r.constraint()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !satisfies(baseVersion) {
+		if r.Debug {
+			fmt.Printf("ignoring base version %s as it does not satisfy constraint %s\n", baseVersion, r.Constraint)
+		}
+		baseVersion = scheme.Zero()
+	}
+
 	// Find and sort the version tags
-	var versions []*semver.Version
+	var versions []Version
 	for _, t := range tags {
-		if v, _ := NewSemVer(t); v != nil {
-			if r.SameRelease && !MajorMinorEqual(baseVersion, v) {
+		if v, _ := scheme.Parse(t); v != nil {
+			if r.SameRelease && !sameRelease.SameRelease(baseVersion, v) {
+				continue
+			}
+			if !satisfies(v) {
+				continue
+			}
+			if r.BranchPreRelease && isPreRelease(v) {
 				continue
 			}
 			versions = append(versions, v)
@@ -188,19 +425,64 @@ func (r NewRelVer) GetLatestVersion(gitClient GitClient) (latest, base *semver.V
 	if len(versions) == 0 {
 		return nil, baseVersion, nil
 	}
-	semver.Sort(versions)
+	sort.Slice(versions, func(i, j int) bool {
+		return scheme.Compare(versions[i], versions[j]) < 0
+	})
 	latestVersion := versions[len(versions)-1]
 
 	// Return latest version unless base version is higher
-	if baseVersion.Compare(*latestVersion) > 0 {
+	if scheme.Compare(baseVersion, latestVersion) > 0 {
 		return nil, baseVersion, nil
 	}
 	return latestVersion, baseVersion, nil
 }
 
+// constraint returns a func that returns true if a version satisfies NewRelVer.Constraint, or always returns true if no constraint is set.
+func (r NewRelVer) constraint() (func(Version) bool, error) {
+	if r.Constraint == "" {
+		return func(Version) bool { return true }, nil
+	}
+	scheme, ok := r.scheme().(ConstraintScheme)
+	if !ok {
+		return nil, errors.New("constraints are not supported by the version scheme")
+	}
+	satisfies, err := scheme.NewConstraint(r.Constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %v", r.Constraint, err)
+	}
+	return satisfies, nil
+}
+
+// checkConstraint returns an error if v does not satisfy NewRelVer.Constraint.
+func (r NewRelVer) checkConstraint(v Version) error {
+	satisfies, err := r.constraint()
+	if err != nil {
+		return err
+	}
+	if !satisfies(v) {
+		return fmt.Errorf("new version %s does not satisfy constraint %s", v, r.Constraint)
+	}
+	return nil
+}
+
+// checkNewVersion returns an error if v does not satisfy NewRelVer.Constraint or does not match the major version suffix of a Go module.
+func (r NewRelVer) checkNewVersion(v Version) error {
+	if err := r.checkConstraint(v); err != nil {
+		return err
+	}
+	return r.checkGoModuleMajor(v)
+}
+
+// isPreRelease returns true if v is a semver pre-release version.
+func isPreRelease(v Version) bool {
+	sv, ok := v.(*semver.Version)
+	return ok && sv.PreRelease != ""
+}
+
 // GetBaseVersion returns the project's base version.
 //
-// The base version is found by searching a known set of project config files for a known version identifier.
+// The base version is found by searching a known set of project config files for a known version identifier.  A Dockerfile is only searched when no
+// other project config file sets a version.
 //
 // E.g.
 //
@@ -210,25 +492,72 @@ func (r NewRelVer) GetLatestVersion(gitClient GitClient) (latest, base *semver.V
 //
 // - If NewRelVer.baseVersion is set, then that version is returned.
 //
+// - If the version scheme does not use version files, such as CalVer, then the scheme's zero version is returned.
+//
 // WARNING: GetBaseVersion does not search for project config files in a deterministic order, so if you have more than one supported project config file in your
 // repo, make sure only one has a version identifier.
-func (r NewRelVer) GetBaseVersion() (*semver.Version, error) {
+func (r NewRelVer) GetBaseVersion() (Version, error) {
+	scheme := r.scheme()
 	if r.BaseVersion != "" {
-		return NewSemVer(r.BaseVersion)
-	}
-	for verFile, verFunc := range versionFiles {
-		if file, err := r.FindVersionFile(verFile); err == nil {
-			if v, err := verFunc(file); err == nil {
-				return NewSemVer(v)
-			} else if r.Debug {
-				fmt.Printf("%v\n", err)
+		return scheme.Parse(r.BaseVersion)
+	}
+	if vf, ok := scheme.(VersionFileScheme); ok && !vf.UsesVersionFiles() {
+		return scheme.Zero(), nil
+	}
+	if r.GoVersionFile != "" {
+		v, err := r.findGoVersion()
+		if err != nil {
+			return nil, err
+		}
+		return scheme.Parse(v)
+	}
+	if r.Dockerfile != "" {
+		file, err := r.FindVersionFile(r.Dockerfile)
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.findDockerfileVersion(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", r.Dockerfile, err)
+		}
+		return scheme.Parse(v)
+	}
+	for _, files := range []map[string]findVersion{versionFiles, fallbackVersionFiles} {
+		for pattern, verFunc := range files {
+			for _, verFile := range r.versionFileNames(pattern) {
+				if file, err := r.FindVersionFile(verFile); err == nil {
+					if v, err := verFunc(r, file); err == nil {
+						return scheme.Parse(v)
+					} else if r.Debug {
+						fmt.Printf("%v\n", err)
+					}
+				}
 			}
 		}
 	}
 	if r.Debug {
 		fmt.Println("No version file found")
 	}
-	return &semver.Version{}, nil
+	return scheme.Zero(), nil
+}
+
+// versionFileNames returns the paths, relative to NewRelVer.Dir, of the files matching a versionFiles pattern, such as *.csproj or lib/*/version.rb, in
+// lexical order.  A pattern without wildcards is returned as is.
+func (r NewRelVer) versionFileNames(pattern string) []string {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}
+	}
+	matches, _ := filepath.Glob(filepath.Join(r.Dir, pattern))
+	var names []string
+	for _, m := range matches {
+		if info, err := os.Stat(m); err != nil || info.IsDir() {
+			continue
+		}
+		if name, err := filepath.Rel(r.Dir, m); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // FindVersionFile returns the contents of the given file from NewRelVer.dir directory.
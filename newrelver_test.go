@@ -370,6 +370,39 @@ func TestPomXML(t *testing.T) {
 	assert.Equal(t, "1.0.0-SNAPSHOT", v.String())
 }
 
+func TestPomXMLProperties(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/java/pom.xml/ci-friendly",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3-SNAPSHOT", v.String())
+}
+
+func TestPomXMLParent(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/java/pom.xml/ci-friendly/module",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3-SNAPSHOT", v.String())
+}
+
+func TestPomXMLParentRelativePath(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/java/pom.xml/relative-path/child",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
 func TestBuildGradleKTS(t *testing.T) {
 	r := NewRelVer{
 		Dir: "examples/kotlin",
@@ -403,6 +436,28 @@ func TestSetupCfg(t *testing.T) {
 	assert.Equal(t, "1.2.3", v.String())
 }
 
+func TestSetupCfgAttr(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/setup.cfg/attr",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestSetupCfgFile(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/setup.cfg/file",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
 func TestSetupPy(t *testing.T) {
 	r := NewRelVer{
 		Dir: "examples/python/setup.py",
@@ -436,6 +491,50 @@ func TestSetupPyOneLine(t *testing.T) {
 	assert.Equal(t, "4.5.6", v.String())
 }
 
+func TestPyprojectToml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/pyproject.toml/pep621",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestPyprojectTomlPoetry(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/pyproject.toml/poetry",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestPyprojectTomlHatch(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/pyproject.toml/hatch",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestPyprojectTomlSetuptools(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/python/pyproject.toml/setuptools",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "4.5.6", v.String())
+}
+
 func TestMakefile(t *testing.T) {
 	r := NewRelVer{
 		Dir: "examples/make",
@@ -457,3 +556,831 @@ func TestCMakefile(t *testing.T) {
 
 	assert.Equal(t, "1.2.0-SNAPSHOT", v.String())
 }
+
+func TestGetNewVersionBranchPreRelease(t *testing.T) {
+	r := NewRelVer{
+		Dir:              "examples",
+		BranchPreRelease: true,
+		Branch:           "feature/login",
+		DefaultBranch:    "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v99.0.18-feature-login.1", "v99.0.18-feature-login.2", "v99.0.18-pr-7.5"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "99.0.18-feature-login.3", v.String())
+}
+
+func TestGetNewVersionBranchPreReleaseFirstBuild(t *testing.T) {
+	r := NewRelVer{
+		Dir:              "examples",
+		BranchPreRelease: true,
+		Branch:           "pr-123",
+		DefaultBranch:    "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(Tags, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "99.0.18-pr-123.1", v.String())
+}
+
+func TestGetNewVersionBranchPreReleaseDefaultBranch(t *testing.T) {
+	r := NewRelVer{
+		Dir:              "examples",
+		BranchPreRelease: true,
+		Branch:           "main",
+		DefaultBranch:    "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v99.0.18-feature-login.1"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "99.0.18", v.String())
+}
+
+func TestGetNewVersionConstraint(t *testing.T) {
+	r := NewRelVer{
+		Dir:        "examples",
+		Constraint: "~1.0",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v1.1.0", "v1.1.1"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.3", v.String())
+}
+
+func TestGetNewVersionConstraintRange(t *testing.T) {
+	r := NewRelVer{
+		Dir:        "examples",
+		Constraint: ">=1.0 <99",
+		Minor:      true,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v1.1.0", "v1.1.1"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.0", v.String())
+}
+
+func TestGetNewVersionConstraintExceeded(t *testing.T) {
+	r := NewRelVer{
+		Dir:        "examples",
+		Constraint: "1.0.x",
+		Minor:      true,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(Tags, nil)
+
+	_, err := r.GetNewVersion(mockClient)
+	assert.Error(t, err)
+}
+
+func TestGetNewVersionReleaseBranch(t *testing.T) {
+	r := NewRelVer{
+		Dir:              "examples",
+		ReleaseBranch:    true,
+		Branch:           "hotfix/1.0.x",
+		BranchPreRelease: true,
+		DefaultBranch:    "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(Tags, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.3", v.String())
+}
+
+func TestGetNewVersionReleaseBranchPattern(t *testing.T) {
+	r := NewRelVer{
+		Dir:                  "examples",
+		ReleaseBranch:        true,
+		ReleaseBranchPattern: `^maint-(\d+\.\d+)$`,
+		Branch:               "maint-99.0",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v99.1.0"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "99.0.18", v.String())
+}
+
+func TestGetNewVersionNotReleaseBranch(t *testing.T) {
+	r := NewRelVer{
+		Dir:           "examples",
+		ReleaseBranch: true,
+		Branch:        "main",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "v99.1.0"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "99.1.1", v.String())
+}
+
+func TestCargoToml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/rust/package",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestCargoTomlWorkspace(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/rust/workspace",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestCargoTomlWorkspaceMember(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/rust/workspace/member",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestCargoTomlWorkspaceRootPackage(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/rust/root",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.4.2", v.String())
+}
+
+func TestChartYaml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/helm",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestChartYamlAppVersion(t *testing.T) {
+	r := NewRelVer{
+		Dir:             "examples/helm",
+		ChartVersionKey: ChartAppVersion,
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "4.5.6", v.String())
+}
+
+func TestCsproj(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dotnet/csproj",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestDirectoryBuildProps(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dotnet/Directory.Build.props",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4-beta1", v.String())
+}
+
+func TestNuspec(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dotnet/nuspec",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestGoVersionFile(t *testing.T) {
+	r := NewRelVer{
+		Dir:           "examples/go",
+		GoVersionFile: "version/version.go",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestGetNewVersionGoModule(t *testing.T) {
+	r := NewRelVer{
+		Dir:           "examples/go",
+		GoVersionFile: "version/version.go",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v2.3.3", "v2.3.4"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.5", v.String())
+}
+
+func TestGetNewVersionGoModuleMajorMismatch(t *testing.T) {
+	r := NewRelVer{
+		Dir:           "examples/go",
+		GoVersionFile: "version/version.go",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v2.3.4", "v3.0.0"}, nil)
+
+	_, err := r.GetNewVersion(mockClient)
+	assert.EqualError(t, err, "new version 3.0.1 needs a /v3 module path suffix, but go.mod has module github.com/trendmicro/new-release-version/examples/go/v2")
+}
+
+func TestGemspec(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/ruby/gemspec",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestGemspecVersionConstant(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/ruby/constant",
+	}
+
+	file, err := r.FindVersionFile("my_gem.gemspec")
+	assert.NoError(t, err)
+
+	v, err := r.findGemspecVersion(file)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v)
+}
+
+func TestVersionRb(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/ruby/version.rb",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestRubyFileName(t *testing.T) {
+	assert.Equal(t, "my_gem", rubyFileName("MyGem"))
+	assert.Equal(t, "http_client", rubyFileName("HTTPClient"))
+	assert.Equal(t, "foo", rubyFileName("Foo"))
+}
+
+func TestComposerJson(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/php",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestPubspecYaml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dart",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3+45", v.String())
+}
+
+func TestGetNewVersionPubspecYaml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dart",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.2+44", "v1.2.3+45"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.4+46", v.String())
+}
+
+func TestGetNewVersionPubspecYamlPlainTags(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/dart",
+	}
+
+	versions := []string{}
+	for _, tags := range [][]string{{"v1.2.3"}, {"v1.2.3", "v1.2.4"}} {
+		mockClient := &GitClientMock{}
+		mockClient.On("ListTags").Return(tags, nil)
+
+		v, err := r.GetNewVersion(mockClient)
+		assert.NoError(t, err)
+		versions = append(versions, v.String())
+	}
+
+	assert.Equal(t, []string{"1.2.4+46", "1.2.5+47"}, versions)
+}
+
+func TestGetNewVersionNumericBuildMetadata(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.2+6", "v1.2.3+7"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.4", v.String())
+}
+
+func TestBuildSbt(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/scala",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestProjectClj(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/clojure",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestGradleVersionCatalog(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/kotlin/catalog",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestConfigureAc(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/autotools",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestMesonBuild(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/meson",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestMesonBuildNoVersion(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/meson/no_version",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0.0.0", v.String())
+}
+
+func TestModuleBazel(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/bazel",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestModuleBazelNoVersion(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/bazel/no_version",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0.0.0", v.String())
+}
+
+func TestVersionFile(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/plain/VERSION",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestVersionTxtComments(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/plain/comments",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestLowerCaseVersionTxt(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/plain/lowercase",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestMixExs(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/elixir/mix.exs",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestMixExsAttribute(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/elixir/attribute",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestCabal(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/haskell",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestDescription(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/r",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0.3.1", v.String())
+}
+
+func TestProjectToml(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/julia",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "4.5.6", v.String())
+}
+
+func TestLernaJson(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/nodejs/lerna/fixed",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestGetNewVersionLernaIndependent(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/nodejs/lerna/independent/packages/foo",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v9.9.9", "@test/foo@0.2.0", "@test/foo@0.2.1", "@test/bar@1.0.0"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0.2.2", v.String())
+}
+
+func TestGetNewVersionLernaNotPackage(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/nodejs/lerna/invalid/service",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.4.0"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.4.1", v.String())
+}
+
+func TestGetNewVersionTagPrefix(t *testing.T) {
+	r := NewRelVer{
+		Dir:       "examples",
+		TagPrefix: "tools/v",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "tools/v0.3.0", "tools/v0.3.1"), nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "0.3.2", v.String())
+}
+
+func TestDenoJsonc(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/deno",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestJsrJson(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/jsr",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestBuildGradleAndroid(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/groovy",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestBuildGradleAndroidFlavors(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/flavors",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestBuildGradleKtsAndroid(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/kts",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestInfoPlist(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/ios",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestGetNewVersionCode(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/groovy",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.1", "v1.2.2", "v1.2.3"}, nil)
+
+	code, err := r.GetNewVersionCode(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 57, code)
+}
+
+func TestGetNewVersionCodeFlavors(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/flavors",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.3"}, nil)
+
+	code, err := r.GetNewVersionCode(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 42, code)
+}
+
+func TestGetNewVersionCodeTags(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/ios",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(append(Tags, "not-a-version"), nil)
+
+	code, err := r.GetNewVersionCode(mockClient)
+	assert.NoError(t, err)
+
+	// CURRENT_PROJECT_VERSION 12 plus the 18 tags above MARKETING_VERSION 3.4.5
+	assert.Equal(t, 31, code)
+}
+
+func TestGetNewVersionCodeReleases(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/android/groovy",
+	}
+
+	codes := []int{}
+	for _, tags := range [][]string{{"v1.2.3"}, {"v1.2.3", "v1.2.4"}, {"v1.2.3", "v1.2.4", "v1.2.5"}} {
+		mockClient := &GitClientMock{}
+		mockClient.On("ListTags").Return(tags, nil)
+
+		code, err := r.GetNewVersionCode(mockClient)
+		assert.NoError(t, err)
+		codes = append(codes, code)
+	}
+
+	assert.Equal(t, []int{57, 58, 59}, codes)
+}
+
+func TestGetNewVersionCodeNoCode(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples",
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.3", "v1.2.4"}, nil)
+
+	code, err := r.GetNewVersionCode(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, code)
+}
+
+func TestDockerfileLabel(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/docker/label",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", v.String())
+}
+
+func TestDockerfileArg(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/docker/arg",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.3.4", v.String())
+}
+
+func TestContainerfile(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/docker/containerfile",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.4.5", v.String())
+}
+
+func TestDockerfileWithVersionFile(t *testing.T) {
+	r := NewRelVer{
+		Dir: "examples/docker/package.json",
+	}
+
+	// Map iteration order is random, so check the version file wins every time
+	for i := 0; i < 10; i++ {
+		v, err := r.GetBaseVersion()
+		assert.NoError(t, err)
+
+		assert.Equal(t, "2.0.0", v.String())
+	}
+}
+
+func TestNamedDockerfile(t *testing.T) {
+	r := NewRelVer{
+		Dir:              "examples/docker/named",
+		Dockerfile:       "docker/app.Dockerfile",
+		DockerVersionArg: "APP_VERSION",
+	}
+
+	v, err := r.GetBaseVersion()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "4.5.6", v.String())
+}
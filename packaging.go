@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	debianUpstreamRegex = regexp.MustCompile(`^[0-9][A-Za-z0-9.+~-]*$`)
+	debianRevisionRegex = regexp.MustCompile(`^[A-Za-z0-9.+~]+$`)
+	rpmVersionRegex     = regexp.MustCompile(`^[0-9][A-Za-z0-9._+~^]*$`)
+	rpmReleaseRegex     = regexp.MustCompile(`^[A-Za-z0-9._+~^]+$`)
+	leadingNumbersRegex = regexp.MustCompile(`^\d+(?:\.\d+)*`)
+	numberRegex         = regexp.MustCompile(`\d+`)
+	epochRegex          = regexp.MustCompile(`^(\d+):`)
+)
+
+// debianTagUnmangler reverses the DEP-14 mangling of Debian versions in git tags, see https://dep-team.pages.debian.net/deps/dep14/.
+var debianTagUnmangler = strings.NewReplacer("%", ":", "_", "~")
+
+// PackageVersion is a Debian or RPM package version made of an epoch, an upstream version and a package revision, e.g. 1:1.2.3-2.
+//
+// The revision is called the release in RPM and is empty if the version has none.
+type PackageVersion struct {
+	Epoch    int
+	Upstream string
+	Revision string
+}
+
+// String returns the version as [epoch:]upstream[-revision].
+func (v *PackageVersion) String() string {
+	s := v.Upstream
+	if v.Epoch != 0 {
+		s = fmt.Sprintf("%d:%s", v.Epoch, s)
+	}
+	if v.Revision != "" {
+		s += "-" + v.Revision
+	}
+	return s
+}
+
+// parsePackageVersion splits a version into its epoch, upstream version and revision, checking the latter two against the given regexes.
+func parsePackageVersion(v string, upstreamRegex, revisionRegex *regexp.Regexp) (*PackageVersion, error) {
+	pv := &PackageVersion{Upstream: v}
+	if m := epochRegex.FindStringSubmatch(v); m != nil {
+		pv.Epoch, _ = strconv.Atoi(m[1])
+		pv.Upstream = strings.TrimPrefix(v, m[0])
+	}
+	if i := strings.LastIndex(pv.Upstream, "-"); i >= 0 {
+		pv.Upstream, pv.Revision = pv.Upstream[:i], pv.Upstream[i+1:]
+		if !revisionRegex.MatchString(pv.Revision) {
+			return nil, fmt.Errorf("invalid package revision in version %q", v)
+		}
+	}
+	if !upstreamRegex.MatchString(pv.Upstream) {
+		return nil, fmt.Errorf("invalid upstream version in version %q", v)
+	}
+	return pv, nil
+}
+
+// bumpUpstream increments the patch or minor component of an upstream version, dropping anything after its leading numeric components.
+//
+// E.g. 1.2.3+dfsg is incremented to 1.2.4 (or 1.3.0 for a minor increment).
+func bumpUpstream(upstream string, part BumpPart) string {
+	var nums []int
+	for _, n := range strings.Split(leadingNumbersRegex.FindString(upstream), ".") {
+		i, _ := strconv.Atoi(n)
+		nums = append(nums, i)
+	}
+	for len(nums) < 3 {
+		nums = append(nums, 0)
+	}
+	if part == BumpMinor {
+		nums[1]++
+		for i := 2; i < len(nums); i++ {
+			nums[i] = 0
+		}
+	} else {
+		nums[len(nums)-1]++
+	}
+	fields := make([]string, len(nums))
+	for i, n := range nums {
+		fields[i] = strconv.Itoa(n)
+	}
+	return strings.Join(fields, ".")
+}
+
+// incrementNumber increments the first (or last) number in s, or appends 1 if s has no number.
+func incrementNumber(s string, last bool) string {
+	locs := numberRegex.FindAllStringIndex(s, -1)
+	if len(locs) == 0 {
+		return s + "1"
+	}
+	loc := locs[0]
+	if last {
+		loc = locs[len(locs)-1]
+	}
+	n, _ := strconv.Atoi(s[loc[0]:loc[1]])
+	return s[:loc[0]] + strconv.Itoa(n+1) + s[loc[1]:]
+}
+
+// samePackageRelease returns true if a and b share the same epoch and major and minor upstream version numbers.
+func samePackageRelease(a, b Version) bool {
+	av, bv := a.(*PackageVersion), b.(*PackageVersion)
+	an := append(strings.Split(leadingNumbersRegex.FindString(av.Upstream), "."), "0")
+	bn := append(strings.Split(leadingNumbersRegex.FindString(bv.Upstream), "."), "0")
+	return av.Epoch == bv.Epoch && compareNumbers(an[0], bn[0]) == 0 && compareNumbers(an[1], bn[1]) == 0
+}
+
+// compareNumbers compares two strings of digits of any length numerically.  This returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func compareNumbers(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := compareInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// DebianScheme is a VersionScheme for Debian package versions, see https://www.debian.org/doc/debian-policy/ch-controlfields.html#version.
+//
+// Versions are *PackageVersion structs.  Git tags may be prefixed with v or debian/ and use DEP-14 mangling, e.g. debian/1%1.2.3_rc1-1 is 1:1.2.3~rc1-1.
+type DebianScheme struct{}
+
+// Parse converts a version string into a *PackageVersion.
+func (DebianScheme) Parse(v string) (Version, error) {
+	if strings.HasPrefix(v, "debian/") {
+		v = debianTagUnmangler.Replace(strings.TrimPrefix(v, "debian/"))
+	}
+	pv, err := parsePackageVersion(strings.TrimPrefix(v, "v"), debianUpstreamRegex, debianRevisionRegex)
+	if err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b, using the same algorithm as dpkg --compare-versions.
+func (DebianScheme) Compare(a, b Version) int {
+	av, bv := a.(*PackageVersion), b.(*PackageVersion)
+	if c := compareInt(av.Epoch, bv.Epoch); c != 0 {
+		return c
+	}
+	if c := debianVerRevCmp(av.Upstream, bv.Upstream); c != 0 {
+		return c
+	}
+	return debianVerRevCmp(av.Revision, bv.Revision)
+}
+
+// Bump increments the upstream version, resetting any revision to 1, or increments the last number of the revision.
+//
+// E.g. 1.2.3-2 is incremented to 1.2.4-1 (or 1.2.3-3 for a revision increment) and 1.2.3-0ubuntu1 to 1.2.3-0ubuntu2.
+func (DebianScheme) Bump(v Version, part BumpPart) (Version, error) {
+	pv := v.(*PackageVersion)
+	next := *pv
+	switch part {
+	case BumpPatch, BumpMinor:
+		next.Upstream = bumpUpstream(pv.Upstream, part)
+		if pv.Revision != "" {
+			next.Revision = "1"
+		}
+	case BumpRevision:
+		next.Revision = incrementNumber(pv.Revision, true)
+	default:
+		return nil, fmt.Errorf("%s increment is not supported by the debian scheme", part)
+	}
+	return &next, nil
+}
+
+// Format returns the string form of v.
+func (DebianScheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns 0.0.0.
+func (DebianScheme) Zero() Version {
+	return &PackageVersion{Upstream: "0.0.0"}
+}
+
+// SameRelease returns true if a and b share the same epoch and major and minor upstream version numbers.
+func (DebianScheme) SameRelease(a, b Version) bool {
+	return samePackageRelease(a, b)
+}
+
+// debianOrder returns the sort weight of a character in the non-digit part of a Debian version, where ~ sorts before anything, even the end of a part,
+// and letters sort before non-letters.
+func debianOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case c == '~':
+		return -1
+	case c >= '0' && c <= '9':
+		return 0
+	case c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z':
+		return int(c)
+	}
+	return int(c) + 256
+}
+
+// debianVerRevCmp compares Debian upstream versions or revisions like dpkg's verrevcmp.  This returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func debianVerRevCmp(a, b string) int {
+	isDigit := func(s string, i int) bool {
+		return i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a, i) || j < len(b) && !isDigit(b, j) {
+			if c := compareInt(debianOrder(a, i), debianOrder(b, j)); c != 0 {
+				return c
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		diff := 0
+		for isDigit(a, i) && isDigit(b, j) {
+			if diff == 0 {
+				diff = compareInt(int(a[i]), int(b[j]))
+			}
+			i++
+			j++
+		}
+		if isDigit(a, i) {
+			return 1
+		}
+		if isDigit(b, j) {
+			return -1
+		}
+		if diff != 0 {
+			return diff
+		}
+	}
+	return 0
+}
+
+// RPMScheme is a VersionScheme for RPM package versions, see https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning.
+//
+// Versions are *PackageVersion structs, where the revision is the RPM release.
+type RPMScheme struct{}
+
+// Parse converts a version string into a *PackageVersion.
+func (RPMScheme) Parse(v string) (Version, error) {
+	pv, err := parsePackageVersion(strings.TrimPrefix(v, "v"), rpmVersionRegex, rpmReleaseRegex)
+	if err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b, using the same algorithm as rpmvercmp.
+//
+// Releases are only compared if both versions have one.
+func (RPMScheme) Compare(a, b Version) int {
+	av, bv := a.(*PackageVersion), b.(*PackageVersion)
+	if c := compareInt(av.Epoch, bv.Epoch); c != 0 {
+		return c
+	}
+	if c := rpmVerCmp(av.Upstream, bv.Upstream); c != 0 {
+		return c
+	}
+	if av.Revision == "" || bv.Revision == "" {
+		return 0
+	}
+	return rpmVerCmp(av.Revision, bv.Revision)
+}
+
+// Bump increments the version, resetting the leading number of any release to 1, or increments the leading number of the release.
+//
+// E.g. 1.2.3-2.el9 is incremented to 1.2.4-1.el9 (or 1.2.3-3.el9 for a release increment).
+func (RPMScheme) Bump(v Version, part BumpPart) (Version, error) {
+	pv := v.(*PackageVersion)
+	next := *pv
+	switch part {
+	case BumpPatch, BumpMinor:
+		next.Upstream = bumpUpstream(pv.Upstream, part)
+		if loc := numberRegex.FindStringIndex(pv.Revision); loc != nil {
+			next.Revision = pv.Revision[:loc[0]] + "1" + pv.Revision[loc[1]:]
+		}
+	case BumpRevision:
+		next.Revision = incrementNumber(pv.Revision, false)
+	default:
+		return nil, fmt.Errorf("%s increment is not supported by the rpm scheme", part)
+	}
+	return &next, nil
+}
+
+// Format returns the string form of v.
+func (RPMScheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns 0.0.0.
+func (RPMScheme) Zero() Version {
+	return &PackageVersion{Upstream: "0.0.0"}
+}
+
+// SameRelease returns true if a and b share the same epoch and major and minor version numbers.
+func (RPMScheme) SameRelease(a, b Version) bool {
+	return samePackageRelease(a, b)
+}
+
+// rpmVerCmp compares RPM versions or releases like rpm's rpmvercmp.  This returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func rpmVerCmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isAlnum := func(c byte) bool {
+		return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+	}
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
+	}
+	isAlpha := func(c byte) bool {
+		return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+	}
+
+	for len(a) > 0 || len(b) > 0 {
+		for len(a) > 0 && !isAlnum(a[0]) && a[0] != '~' && a[0] != '^' {
+			a = a[1:]
+		}
+		for len(b) > 0 && !isAlnum(b[0]) && b[0] != '~' && b[0] != '^' {
+			b = b[1:]
+		}
+
+		// Tilde sorts before everything else
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		// Caret sorts like tilde, except that it sorts after the end of a version
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+
+		segment := isAlpha
+		isNum := isDigit(a[0])
+		if isNum {
+			segment = isDigit
+		}
+		i, j := 0, 0
+		for i < len(a) && segment(a[i]) {
+			i++
+		}
+		for j < len(b) && segment(b[j]) {
+			j++
+		}
+		if j == 0 {
+			// Numeric segments are newer than alpha segments
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		var c int
+		if isNum {
+			c = compareNumbers(a[:i], b[:j])
+		} else {
+			c = strings.Compare(a[:i], b[:j])
+		}
+		if c != 0 {
+			return c
+		}
+		a, b = a[i:], b[j:]
+	}
+	return compareInt(len(a), len(b))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertSchemeOrder(t *testing.T, s VersionScheme, ordered []string) {
+	for i := range ordered {
+		for j := range ordered {
+			a, err := s.Parse(ordered[i])
+			assert.NoError(t, err)
+			b, err := s.Parse(ordered[j])
+			assert.NoError(t, err)
+			assert.Equal(t, compareInt(i, j), s.Compare(a, b), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestDebianSchemeOrder(t *testing.T) {
+	assertSchemeOrder(t, DebianScheme{}, []string{
+		"1.0~~",
+		"1.0~~a",
+		"1.0~",
+		"1.0",
+		"1.0-0ubuntu1",
+		"1.0-1",
+		"1.0-1+deb12u1",
+		"1.0a",
+		"1.0+dfsg-1",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"1:0.1",
+	})
+}
+
+func TestDebianSchemeParseTag(t *testing.T) {
+	v, err := DebianScheme{}.Parse("debian/2%1.2.3_rc1-1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, &PackageVersion{Epoch: 2, Upstream: "1.2.3~rc1", Revision: "1"}, v)
+	assert.Equal(t, "2:1.2.3~rc1-1", v.String())
+}
+
+func TestDebianSchemeBump(t *testing.T) {
+	s := DebianScheme{}
+	v, err := s.Parse("1:1.2.3+dfsg-0ubuntu2")
+	assert.NoError(t, err)
+
+	patch, err := s.Bump(v, BumpPatch)
+	assert.NoError(t, err)
+	revision, err := s.Bump(v, BumpRevision)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1:1.2.4-1", s.Format(patch))
+	assert.Equal(t, "1:1.2.3+dfsg-0ubuntu3", s.Format(revision))
+}
+
+func TestRPMSchemeOrder(t *testing.T) {
+	assertSchemeOrder(t, RPMScheme{}, []string{
+		"1.0~rc1",
+		"1.0",
+		"1.0^git1",
+		"1.0a",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"1:0.1",
+	})
+
+	s := RPMScheme{}
+	a, _ := s.Parse("1.0-2.el9")
+	b, _ := s.Parse("1.0-10.el9")
+	c, _ := s.Parse("1.0")
+	assert.Equal(t, -1, s.Compare(a, b))
+	assert.Equal(t, 0, s.Compare(a, c))
+}
+
+func TestRPMSchemeBump(t *testing.T) {
+	s := RPMScheme{}
+	v, err := s.Parse("1.2.3-2.el9")
+	assert.NoError(t, err)
+
+	minor, err := s.Bump(v, BumpMinor)
+	assert.NoError(t, err)
+	revision, err := s.Bump(v, BumpRevision)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.3.0-1.el9", s.Format(minor))
+	assert.Equal(t, "1.2.3-3.el9", s.Format(revision))
+}
+
+func TestGetNewVersionDebianRevision(t *testing.T) {
+	r := NewRelVer{
+		Dir:      "examples",
+		Revision: true,
+		Scheme:   DebianScheme{},
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"debian/1.2.2-4", "debian/1.2.3-1", "debian/1.2.3-2", "debian/1.2.3_rc1-7"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3-3", v.String())
+}
+
+func TestGetNewVersionRevisionUnsupported(t *testing.T) {
+	r := NewRelVer{
+		Dir:      "examples",
+		Revision: true,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(Tags, nil)
+
+	_, err := r.GetNewVersion(mockClient)
+	assert.Error(t, err)
+}
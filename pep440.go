@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Regex is the version regex from PEP 440 appendix B, see https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions.
+var pep440Regex = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>a|b|c|rc|alpha|beta|pre|preview)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// pep440PreLabels maps pre-release spellings to their normalized form.
+var pep440PreLabels = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// pep440PreOrder is the order of normalized pre-release labels.
+var pep440PreOrder = map[string]int{"a": 0, "b": 1, "rc": 2}
+
+// PEP440Version is a Python package version, see https://peps.python.org/pep-0440/.
+//
+// Pre, Post and Dev are nil when the version has no such segment.
+type PEP440Version struct {
+	Epoch   int
+	Release []int
+	PreL    string
+	Pre     *int
+	Post    *int
+	Dev     *int
+	Local   string
+}
+
+// NewPEP440Version parses and normalizes a PEP 440 version string, e.g. 1.2.0-RC.1 is normalized to 1.2.0rc1.
+func NewPEP440Version(v string) (*PEP440Version, error) {
+	m := pep440Regex.FindStringSubmatch(v)
+	if m == nil {
+		return nil, fmt.Errorf("invalid PEP 440 version %q", v)
+	}
+	group := func(name string) string {
+		return m[pep440Regex.SubexpIndex(name)]
+	}
+	number := func(s string) *int {
+		n, _ := strconv.Atoi(s)
+		return &n
+	}
+
+	ver := &PEP440Version{}
+	if e := group("epoch"); e != "" {
+		ver.Epoch = *number(e)
+	}
+	for _, r := range strings.Split(group("release"), ".") {
+		ver.Release = append(ver.Release, *number(r))
+	}
+	if l := group("pre_l"); l != "" {
+		ver.PreL = pep440PreLabels[strings.ToLower(l)]
+		ver.Pre = number(group("pre_n"))
+	}
+	if n := group("post_n1"); n != "" {
+		ver.Post = number(n)
+	} else if group("post_l") != "" {
+		ver.Post = number(group("post_n2"))
+	}
+	if group("dev_l") != "" {
+		ver.Dev = number(group("dev_n"))
+	}
+	if l := group("local"); l != "" {
+		ver.Local = strings.ToLower(strings.NewReplacer("-", ".", "_", ".").Replace(l))
+	}
+	return ver, nil
+}
+
+// String returns the normalized version string.
+func (v *PEP440Version) String() string {
+	var sb strings.Builder
+	if v.Epoch != 0 {
+		fmt.Fprintf(&sb, "%d!", v.Epoch)
+	}
+	for i, r := range v.Release {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strconv.Itoa(r))
+	}
+	if v.Pre != nil {
+		fmt.Fprintf(&sb, "%s%d", v.PreL, *v.Pre)
+	}
+	if v.Post != nil {
+		fmt.Fprintf(&sb, ".post%d", *v.Post)
+	}
+	if v.Dev != nil {
+		fmt.Fprintf(&sb, ".dev%d", *v.Dev)
+	}
+	if v.Local != "" {
+		sb.WriteString("+" + v.Local)
+	}
+	return sb.String()
+}
+
+// release returns the i-th release component, or 0 if the release segment is shorter.
+func (v *PEP440Version) release(i int) int {
+	if i < len(v.Release) {
+		return v.Release[i]
+	}
+	return 0
+}
+
+// Compare compares this version to another version.  This returns -1, 0, or 1 if this version is smaller, equal, or larger than the other version.
+//
+// Versions are ordered by epoch, release, pre-release, post-release, dev release and local version, as defined by PEP 440.  Release segments are compared
+// as if padded with zeros, and a dev release of a final release sorts before its pre-releases.
+func (v *PEP440Version) Compare(o *PEP440Version) int {
+	if c := compareInt(v.Epoch, o.Epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(v.Release) || i < len(o.Release); i++ {
+		if c := compareInt(v.release(i), o.release(i)); c != 0 {
+			return c
+		}
+	}
+	if c := compareInt(v.preKey(), o.preKey()); c != 0 {
+		return c
+	}
+	if c := compareOptional(v.Post, o.Post, -1); c != 0 {
+		return c
+	}
+	if c := compareOptional(v.Dev, o.Dev, 1); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, o.Local)
+}
+
+// preKey returns a sort key for the pre-release segment.
+func (v *PEP440Version) preKey() int {
+	switch {
+	case v.Pre != nil:
+		return pep440PreOrder[v.PreL]<<24 | *v.Pre
+	case v.Post == nil && v.Dev != nil:
+		// 1.0.dev1 sorts before 1.0a1
+		return -1
+	}
+	return 3 << 24
+}
+
+// compareInt returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareOptional compares optional numbers, where a missing number sorts before (missing = -1) or after (missing = 1) any number.
+func compareOptional(a, b *int, missing int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return missing
+	case b == nil:
+		return -missing
+	}
+	return compareInt(*a, *b)
+}
+
+// compareLocal compares local version labels.  Numeric segments sort after alphanumeric segments, and a longer label sorts after its prefix.
+func compareLocal(a, b string) int {
+	if a == "" || b == "" {
+		return compareInt(len(a), len(b))
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInt(an, bn)
+		case aErr == nil:
+			c = 1
+		case bErr == nil:
+			c = -1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// PEP440Scheme is a VersionScheme for Python package versions, see https://peps.python.org/pep-0440/.
+//
+// Versions are *PEP440Version structs and are formatted in their normalized form.
+type PEP440Scheme struct{}
+
+// Parse converts a version string into a *PEP440Version.
+func (PEP440Scheme) Parse(v string) (Version, error) {
+	pv, err := NewPEP440Version(v)
+	if err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func (PEP440Scheme) Compare(a, b Version) int {
+	return a.(*PEP440Version).Compare(b.(*PEP440Version))
+}
+
+// Bump increments the patch or minor component of the release segment, keeping the epoch and dropping any pre, post, dev or local segments.
+//
+// E.g. 1.2.0rc1, 1.2.0 and 1.2.0.post1 are all incremented to 1.2.1.
+func (PEP440Scheme) Bump(v Version, part BumpPart) (Version, error) {
+	pv := v.(*PEP440Version)
+	next := &PEP440Version{Epoch: pv.Epoch, Release: []int{pv.release(0), pv.release(1), pv.release(2)}}
+	switch part {
+	case BumpPatch:
+		next.Release[2]++
+	case BumpMinor:
+		next.Release[1]++
+		next.Release[2] = 0
+	default:
+		return nil, fmt.Errorf("%s increment is not supported by the pep440 scheme", part)
+	}
+	return next, nil
+}
+
+// Format returns the normalized form of v.
+func (PEP440Scheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns 0.0.0.
+func (PEP440Scheme) Zero() Version {
+	return &PEP440Version{Release: []int{0, 0, 0}}
+}
+
+// SameRelease returns true if a and b share the same epoch and major and minor release components.
+func (PEP440Scheme) SameRelease(a, b Version) bool {
+	av, bv := a.(*PEP440Version), b.(*PEP440Version)
+	return av.Epoch == bv.Epoch && av.release(0) == bv.release(0) && av.release(1) == bv.release(1)
+}
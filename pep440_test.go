@@ -0,0 +1,96 @@
+package main
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPEP440VersionNormalized(t *testing.T) {
+	for v, normalized := range map[string]string{
+		"1.2.0":            "1.2.0",
+		"v1.2.0-RC.1":      "1.2.0rc1",
+		"1.2.0alpha":       "1.2.0a0",
+		"1.2.0-1":          "1.2.0.post1",
+		"1.2.0.post1":      "1.2.0.post1",
+		"1.2.0.dev3":       "1.2.0.dev3",
+		"2!1.0_b2-dev1":    "2!1.0b2.dev1",
+		"1.0+Ubuntu-1_2":   "1.0+ubuntu.1.2",
+		"1.2.0rc1.post2":   "1.2.0rc1.post2",
+		"1.2.0preview3dev": "1.2.0rc3.dev0",
+	} {
+		pv, err := NewPEP440Version(v)
+		assert.NoError(t, err, v)
+		assert.Equal(t, normalized, pv.String(), v)
+	}
+
+	_, err := NewPEP440Version("1.2.0-SNAPSHOT")
+	assert.Error(t, err)
+}
+
+func TestPEP440VersionOrder(t *testing.T) {
+	ordered := []string{
+		"1.0.dev0",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0a1.post1",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc",
+		"1.0+5",
+		"1.0.post1.dev1",
+		"1.0.post1",
+		"1.0.1",
+		"1.1.dev1",
+		"1!0.1",
+	}
+	var versions []*PEP440Version
+	for i := len(ordered) - 1; i >= 0; i-- {
+		pv, err := NewPEP440Version(ordered[i])
+		assert.NoError(t, err)
+		versions = append(versions, pv)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Compare(versions[j]) < 0 })
+
+	var sorted []string
+	for _, v := range versions {
+		sorted = append(sorted, v.String())
+	}
+	assert.Equal(t, ordered, sorted)
+
+	a, _ := NewPEP440Version("1.0")
+	b, _ := NewPEP440Version("1.0.0")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func TestGetNewVersionPEP440(t *testing.T) {
+	r := NewRelVer{
+		Dir:    "examples",
+		Scheme: PEP440Scheme{},
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v1.2.0", "v1.2.1rc1", "v1.2.0.post1", "v1.3.0.dev2"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.3.1", v.String())
+}
+
+func TestGetNewVersionPEP440BaseVersion(t *testing.T) {
+	r := NewRelVer{
+		Dir:    "examples/python/pep440",
+		Scheme: PEP440Scheme{},
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return([]string{"v2.0.0", "v2.0.1"}, nil)
+
+	v, err := r.GetNewVersion(mockClient)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2.1.0rc1", v.String())
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// maxPomParents limits how many parent POMs are followed, in case of a relativePath cycle.
+const maxPomParents = 10
+
+var pomPropertyRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+type pomProject struct {
+	Version string `xml:"version"`
+	Parent  *struct {
+		Version string `xml:"version"`
+		// RelativePath is nil when not set, which defaults to ../pom.xml, and empty for <relativePath/>, which disables the lookup.
+		RelativePath *string `xml:"relativePath"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+}
+
+// unmarshalPomVersion returns the version of a pom.xml, or the version of its parent when it has none.
+//
+// Placeholders, such as the ${revision} of CI-friendly versions, are resolved from the <properties> of the POM and of its parent POMs, which are found by
+// following <relativePath>.
+func (r NewRelVer) unmarshalPomVersion(data []byte) (string, error) {
+	var pom pomProject
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return "0.0.0", fmt.Errorf("invalid pom.xml: %v", err)
+	}
+
+	version := pom.Version
+	if version == "" && pom.Parent != nil {
+		version = pom.Parent.Version
+	}
+	if version == "" {
+		return "0.0.0", errors.New("No version found")
+	}
+	if !pomPropertyRegex.MatchString(version) {
+		return version, nil
+	}
+
+	properties := map[string]string{}
+	r.loadPomProperties(&pom, filepath.Join(r.Dir, "pom.xml"), properties, 0)
+	for i := 0; i < maxPomParents && pomPropertyRegex.MatchString(version); i++ {
+		version = pomPropertyRegex.ReplaceAllStringFunc(version, func(p string) string {
+			if v, ok := properties[p[2:len(p)-1]]; ok {
+				return v
+			}
+			return p
+		})
+	}
+	if pomPropertyRegex.MatchString(version) {
+		return "0.0.0", fmt.Errorf("unresolved property in version %s", version)
+	}
+	return version, nil
+}
+
+// loadPomProperties adds the properties of pom, read from path, to properties, unless they were already set by a child POM, and then loads those of its
+// parent POMs.
+func (r NewRelVer) loadPomProperties(pom *pomProject, path string, properties map[string]string, depth int) {
+	set := func(k, v string) {
+		if _, ok := properties[k]; !ok && v != "" {
+			properties[k] = v
+		}
+	}
+	for _, p := range pom.Properties.Entries {
+		set(p.XMLName.Local, p.Value)
+	}
+	if pom.Parent == nil {
+		set("project.version", pom.Version)
+		return
+	}
+	set("project.parent.version", pom.Parent.Version)
+	set("project.version", pom.Version)
+	set("project.version", pom.Parent.Version)
+
+	relativePath := "../pom.xml"
+	if pom.Parent.RelativePath != nil {
+		relativePath = *pom.Parent.RelativePath
+	}
+	if relativePath == "" || depth >= maxPomParents {
+		return
+	}
+	parentPath := filepath.Join(filepath.Dir(path), relativePath)
+	if info, err := os.Stat(parentPath); err == nil && info.IsDir() {
+		parentPath = filepath.Join(parentPath, "pom.xml")
+	}
+	data, err := ioutil.ReadFile(parentPath)
+	if err != nil {
+		if r.Debug {
+			fmt.Printf("parent pom not found: %v\n", err)
+		}
+		return
+	}
+	var parent pomProject
+	if err := xml.Unmarshal(data, &parent); err != nil {
+		return
+	}
+	if r.Debug {
+		fmt.Printf("found parent %s\n", parentPath)
+	}
+	r.loadPomProperties(&parent, parentPath, properties, depth+1)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PythonVersionRegexf is the version identifier regex for Python modules, matching e.g. __version__ = "1.2.3".
+//
+// The first %s is replaced with the variable name and the second with VersionNumberRegex.
+const PythonVersionRegexf = `(?m)^%s\s*(?::\s*\w+\s*)?=\s*['"]v?(%s)['"]`
+
+// hatchVersionRegex is Hatch's default pattern for version source files, see https://hatch.pypa.io/latest/version/#configuration.
+var hatchVersionRegex = regexp.MustCompile(`(?im)^(?:__version__|VERSION)\s*(?::\s*\w+\s*)?=\s*['"]v?(?P<version>[^'"]+)['"]`)
+
+type pyproject struct {
+	Project struct {
+		Version string   `toml:"version"`
+		Dynamic []string `toml:"dynamic"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Version string `toml:"version"`
+		} `toml:"poetry"`
+		Hatch struct {
+			Version struct {
+				Path    string `toml:"path"`
+				Pattern string `toml:"pattern"`
+			} `toml:"version"`
+		} `toml:"hatch"`
+		Setuptools struct {
+			Dynamic struct {
+				Version struct {
+					Attr string      `toml:"attr"`
+					File interface{} `toml:"file"`
+				} `toml:"version"`
+			} `toml:"dynamic"`
+		} `toml:"setuptools"`
+	} `toml:"tool"`
+}
+
+func (r NewRelVer) unmarshalPyprojectVersion(data []byte) (string, error) {
+	var project pyproject
+	if _, err := toml.Decode(string(data), &project); err != nil {
+		return "0.0.0", fmt.Errorf("invalid pyproject.toml: %v", err)
+	}
+
+	if project.Project.Version != "" {
+		return project.Project.Version, nil
+	}
+	if project.Tool.Poetry.Version != "" {
+		return project.Tool.Poetry.Version, nil
+	}
+	if !contains(project.Project.Dynamic, "version") {
+		return "0.0.0", errors.New("No version found")
+	}
+
+	if hatch := project.Tool.Hatch.Version; hatch.Path != "" {
+		return r.findHatchVersion(hatch.Path, hatch.Pattern)
+	}
+	setuptools := project.Tool.Setuptools.Dynamic.Version
+	if setuptools.Attr != "" {
+		return r.findPythonAttrVersion(setuptools.Attr)
+	}
+	switch file := setuptools.File.(type) {
+	case string:
+		return r.readPlainVersionFile(file)
+	case []interface{}:
+		if len(file) > 0 {
+			return r.readPlainVersionFile(fmt.Sprint(file[0]))
+		}
+	}
+	return "0.0.0", errors.New("No dynamic version source found")
+}
+
+// findHatchVersion returns the version in the source file at path, relative to NewRelVer.Dir, using Hatch's regex pattern or its default pattern.
+func (r NewRelVer) findHatchVersion(path, pattern string) (string, error) {
+	regex := hatchVersionRegex
+	if pattern != "" {
+		var err error
+		if regex, err = regexp.Compile(pattern); err != nil {
+			return "0.0.0", fmt.Errorf("invalid hatch version pattern: %v", err)
+		}
+	}
+	data, err := r.FindVersionFile(path)
+	if err != nil {
+		return "0.0.0", err
+	}
+	m := regex.FindSubmatch(data)
+	if m == nil {
+		return "0.0.0", fmt.Errorf("No version found in %s", path)
+	}
+	group := regex.SubexpIndex("version")
+	if group < 0 {
+		group = 1
+	}
+	return strings.TrimSpace(string(m[group])), nil
+}
+
+// findPythonAttrVersion returns the version assigned to a module attribute, such as mypkg.__version__, as used by setuptools' attr: directive.
+//
+// The module is looked for relative to NewRelVer.Dir and its src directory.
+func (r NewRelVer) findPythonAttrVersion(attr string) (string, error) {
+	attr = strings.TrimSpace(attr)
+	dot := strings.LastIndex(attr, ".")
+	if dot < 0 {
+		return "0.0.0", fmt.Errorf("invalid attr %q", attr)
+	}
+	module := strings.ReplaceAll(attr[:dot], ".", "/")
+	regex := fmt.Sprintf(PythonVersionRegexf, regexp.QuoteMeta(attr[dot+1:]), VersionNumberRegex)
+
+	for _, root := range []string{"", "src"} {
+		for _, f := range []string{module + ".py", module + "/__init__.py"} {
+			if data, err := r.FindVersionFile(filepath.Join(root, f)); err == nil {
+				return matchVersion(data, regex, 1)
+			}
+		}
+	}
+	return "0.0.0", fmt.Errorf("module %s not found", attr[:dot])
+}
+
+// readPlainVersionFile returns the version in a file, relative to NewRelVer.Dir, that only holds a version number.
+func (r NewRelVer) readPlainVersionFile(path string) (string, error) {
+	data, err := r.FindVersionFile(strings.TrimSpace(path))
+	if err != nil {
+		return "0.0.0", err
+	}
+	return unmarshalPlainVersion(r, data)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupCfgDirectiveRegex matches setup.cfg versions read by setuptools from a module attribute or a file, e.g. version = attr: mypkg.__version__.
+const SetupCfgDirectiveRegex = `(?m)^version\s*=\s*(attr|file):\s*(.+?)\s*$`
+
+func (r NewRelVer) findSetupCfgVersion(data []byte) (string, error) {
+	m := regexp.MustCompile(SetupCfgDirectiveRegex).FindSubmatch(data)
+	if m == nil {
+		return versionMatcher(ConfigRegexf, 1)(r, data)
+	}
+	if string(m[1]) == "attr" {
+		return r.findPythonAttrVersion(string(m[2]))
+	}
+	// file: may list several files, which setuptools concatenates
+	return r.readPlainVersionFile(strings.Split(string(m[2]), ",")[0])
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Version identifier regex strings for Ruby gems.
+//
+// The %s is replaced with VersionNumberRegex.
+const (
+	GemspecRegexf     = `(?m)^\s*\w+\.version\s*=\s*['"](%s)['"]`
+	RubyVersionRegexf = `(?m)^\s*VERSION\s*=\s*['"](%s)['"]`
+)
+
+var (
+	gemspecConstantRegex = regexp.MustCompile(`(?m)^\s*\w+\.version\s*=\s*((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*)::VERSION\b`)
+	gemspecNameRegex     = regexp.MustCompile(`(?m)^\s*\w+\.name\s*=\s*['"]([\w.-]+)['"]`)
+	rubyWordBoundary     = regexp.MustCompile(`([a-z\d])([A-Z])|([A-Z]+)([A-Z][a-z])`)
+)
+
+// findGemspecVersion returns the version of a gemspec, which is either a string or a reference to a VERSION constant, such as Foo::VERSION, that is
+// defined in the gem's lib/foo/version.rb file.
+func (r NewRelVer) findGemspecVersion(data []byte) (string, error) {
+	if v, err := matchVersion(data, fmt.Sprintf(GemspecRegexf, VersionNumberRegex), 1); err == nil {
+		return v, nil
+	}
+
+	var candidates []string
+	if m := gemspecConstantRegex.FindSubmatch(data); m != nil {
+		var dirs []string
+		for _, c := range strings.Split(strings.TrimPrefix(string(m[1]), "::"), "::") {
+			dirs = append(dirs, rubyFileName(c))
+		}
+		candidates = append(candidates, filepath.Join(dirs...))
+	}
+	if m := gemspecNameRegex.FindSubmatch(data); m != nil {
+		// Gem names like foo-bar map to Foo::Bar in lib/foo/bar
+		candidates = append(candidates, strings.ReplaceAll(string(m[1]), "-", "/"))
+	}
+
+	regex := fmt.Sprintf(RubyVersionRegexf, VersionNumberRegex)
+	for _, c := range candidates {
+		if file, err := r.FindVersionFile(filepath.Join("lib", c, "version.rb")); err == nil {
+			return matchVersion(file, regex, 1)
+		}
+	}
+	return "0.0.0", errors.New("No version found")
+}
+
+// rubyFileName converts a Ruby constant name to its conventional file name, e.g. HTTPClient to http_client.
+func rubyFileName(constant string) string {
+	return strings.ToLower(rubyWordBoundary.ReplaceAllString(constant, "${1}${3}_${2}${4}"))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+
+	masterminds "github.com/Masterminds/semver/v3"
+	"github.com/coreos/go-semver/semver"
+)
+
+// Version is a version number parsed by a VersionScheme.
+type Version interface {
+	String() string
+}
+
+// BumpPart is the part of a version that VersionScheme.Bump increments.
+type BumpPart int
+
+// Parts of a version that can be incremented.
+const (
+	BumpPatch BumpPart = iota
+	BumpMinor
+	// BumpRevision increments the package revision of Debian and RPM versions.
+	BumpRevision
+)
+
+// String returns the name of the part.
+func (p BumpPart) String() string {
+	switch p {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpRevision:
+		return "revision"
+	}
+	return fmt.Sprintf("BumpPart(%d)", int(p))
+}
+
+// VersionScheme parses, compares, increments and formats the version numbers of a versioning scheme, such as semver.
+type VersionScheme interface {
+	// Parse converts a version string from a git tag or version file into a Version.
+	Parse(v string) (Version, error)
+	// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+	Compare(a, b Version) int
+	// Bump returns the version following v.  v is not modified.
+	Bump(v Version, part BumpPart) (Version, error)
+	// Format returns the string form of v.
+	Format(v Version) string
+	// Zero returns the version used when a project has no base version.
+	Zero() Version
+}
+
+// SameReleaseScheme is implemented by a VersionScheme that supports NewRelVer.SameRelease.
+type SameReleaseScheme interface {
+	// SameRelease returns true if a and b are versions of the same release.
+	SameRelease(a, b Version) bool
+}
+
+// ConstraintScheme is implemented by a VersionScheme that supports NewRelVer.Constraint.
+type ConstraintScheme interface {
+	// NewConstraint parses a version constraint, such as ~1.4 or >=2.0 <3, into a func that returns true if a version satisfies it.
+	NewConstraint(c string) (func(Version) bool, error)
+}
+
+// VersionFileScheme may be implemented by a VersionScheme to control whether the base version is read from version files.  Version files are used by
+// schemes that do not implement it.
+type VersionFileScheme interface {
+	// UsesVersionFiles returns true if the base version is read from version files.
+	UsesVersionFiles() bool
+}
+
+// NewVersionScheme returns the VersionScheme with the given name.
+//
+// The calVerFormat is only used by the calver scheme.
+func NewVersionScheme(name, calVerFormat string) (VersionScheme, error) {
+	switch name {
+	case "", "semver":
+		return SemVerScheme{}, nil
+	case "calver":
+		s, err := NewCalVerScheme(calVerFormat, nil)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "pep440":
+		return PEP440Scheme{}, nil
+	case "maven":
+		return MavenScheme{}, nil
+	case "debian":
+		return DebianScheme{}, nil
+	case "rpm":
+		return RPMScheme{}, nil
+	}
+	return nil, fmt.Errorf("unknown version scheme %q", name)
+}
+
+// SemVerScheme is the default VersionScheme for semantic versions, see https://semver.org/.
+//
+// Versions are *semver.Version structs.
+type SemVerScheme struct{}
+
+// Parse converts a version string into a *semver.Version using NewSemVer.
+func (SemVerScheme) Parse(v string) (Version, error) {
+	sv, err := NewSemVer(v)
+	if err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b.
+func (SemVerScheme) Compare(a, b Version) int {
+	return a.(*semver.Version).Compare(*b.(*semver.Version))
+}
+
+// Bump increments the patch or minor version of v.
+func (SemVerScheme) Bump(v Version, part BumpPart) (Version, error) {
+	next := *v.(*semver.Version)
+	switch part {
+	case BumpPatch:
+		next.BumpPatch()
+	case BumpMinor:
+		next.BumpMinor()
+	default:
+		return nil, fmt.Errorf("%s increment is not supported by the semver scheme", part)
+	}
+	return &next, nil
+}
+
+// Format returns the string form of v.
+func (SemVerScheme) Format(v Version) string {
+	return v.String()
+}
+
+// Zero returns 0.0.0.
+func (SemVerScheme) Zero() Version {
+	return &semver.Version{}
+}
+
+// SameRelease returns true if a and b share the same major and minor version numbers.
+func (SemVerScheme) SameRelease(a, b Version) bool {
+	return MajorMinorEqual(a.(*semver.Version), b.(*semver.Version))
+}
+
+// NewConstraint parses a semver constraint, such as ~1.4, >=2.0 <3 or 1.x, see https://github.com/Masterminds/semver#checking-version-constraints.
+//
+// Pre-release versions only satisfy constraints that include a pre-release.
+func (SemVerScheme) NewConstraint(c string) (func(Version) bool, error) {
+	constraint, err := masterminds.NewConstraint(c)
+	if err != nil {
+		return nil, err
+	}
+	return func(v Version) bool {
+		cv, err := masterminds.NewVersion(v.String())
+		return err == nil && constraint.Check(cv)
+	}, nil
+}
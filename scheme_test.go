@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionScheme(t *testing.T) {
+	s, err := NewVersionScheme("semver", "")
+	assert.NoError(t, err)
+	assert.Equal(t, SemVerScheme{}, s)
+
+	_, err = NewVersionScheme("unknown", "")
+	assert.Error(t, err)
+}
+
+func TestSemVerSchemeBump(t *testing.T) {
+	s := SemVerScheme{}
+	v, err := s.Parse("v1.2.3")
+	assert.NoError(t, err)
+
+	patch, err := s.Bump(v, BumpPatch)
+	assert.NoError(t, err)
+	minor, err := s.Bump(v, BumpMinor)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", s.Format(v))
+	assert.Equal(t, "1.2.4", s.Format(patch))
+	assert.Equal(t, "1.3.0", s.Format(minor))
+	assert.Equal(t, -1, s.Compare(v, patch))
+}
+
+func TestGetNewVersionSameReleaseUnsupported(t *testing.T) {
+	scheme, err := NewCalVerScheme("", nil)
+	assert.NoError(t, err)
+
+	r := NewRelVer{
+		Dir:         "examples",
+		SameRelease: true,
+		Scheme:      scheme,
+	}
+
+	mockClient := &GitClientMock{}
+	mockClient.On("ListTags").Return(Tags, nil)
+
+	_, err = r.GetNewVersion(mockClient)
+	assert.Error(t, err)
+}